@@ -2,9 +2,12 @@ package wasm
 
 import (
 	"context"
+	"encoding/json"
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	berpcbackend "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/backend"
 	"github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/config"
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	iberpccfg "github.com/bcdevtools/wasm-block-explorer-rpc-cosmos/integrate_be_rpc/config"
 	iberpctypes "github.com/bcdevtools/wasm-block-explorer-rpc-cosmos/integrate_be_rpc/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server"
@@ -21,27 +24,105 @@ type WasmBackendI interface {
 	// GetWasmTransactionByHash returns a transaction by its hash.
 	GetWasmTransactionByHash(hash string) (berpctypes.GenericBackendResponse, error)
 
+	// GetWasmTransactionInvolversByHash returns the involvers of the Wasm messages in the transaction.
 	GetWasmTransactionInvolversByHash(hash string) (berpctypes.MessageInvolversResult, error)
 
 	GetTmTxResult(tmTx tmtypes.Tx) ([]abci.Event, error)
 
 	// CW-20
 
-	GetCw20ContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error)
+	GetCw20ContractInfo(contractAddress string, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error)
 
 	GetCw20TokenInfo(contractAddress string) (*iberpctypes.Cw20TokenInfo, error)
 
-	GetCw20Balance(accountAddress string, contractAddresses []string) (berpctypes.GenericBackendResponse, error)
+	// GetCw20TokenMetadata returns the name, symbol and decimals of the CW-20 token, cached.
+	GetCw20TokenMetadata(contractAddress string) (*iberpctypes.Cw20TokenInfo, error)
+
+	// InvalidateCw20TokenMetadata removes the cached metadata of the CW-20 token, e.g. when the contract is migrated.
+	InvalidateCw20TokenMetadata(contractAddress string)
+
+	// GetCw20TokenMetadataCacheStats returns the hit & miss counters of the CW-20 token metadata cache.
+	GetCw20TokenMetadataCacheStats() berpctypes.GenericBackendResponse
+
+	GetCw20Balance(accountAddress string, contractAddresses []string, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20Balances returns the balances of each account on each of the CW-20 contracts, as an account × contract matrix.
+	GetCw20Balances(accountAddresses []string, contractAddresses []string, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20BalanceHistory returns the balances of the account on the CW-20 contract, at each of the provided heights.
+	GetCw20BalanceHistory(accountAddress, contractAddress string, heights []int64) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20SupplyHistory returns the total supply of the CW-20 token, sampled every `step` blocks within the height range.
+	GetCw20SupplyHistory(contractAddress string, fromHeight, toHeight, step int64) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20Allowance returns the allowance granted by the owner to the spender, on the CW-20 contract.
+	GetCw20Allowance(ownerAddress, spenderAddress, contractAddress string) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20AllAllowances returns the allowances granted by the owner, on the CW-20 contract, paginated.
+	GetCw20AllAllowances(ownerAddress, contractAddress string, startAfter string, limit int) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20AllSpenderAllowances returns the allowances granted to the spender, on the CW-20 contract, paginated.
+	GetCw20AllSpenderAllowances(spenderAddress, contractAddress string, startAfter string, limit int) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20Holders returns the holders of the CW-20 token with balance and share of total supply, paginated,
+	// optionally ranked by balance.
+	GetCw20Holders(contractAddress string, startAfter string, limit int, sortByBalance bool) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20Logo returns the embedded logo of the CW-20 token, with MIME type and base64 data.
+	GetCw20Logo(contractAddress string) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw20Denoms returns the known & discovered CW-20 tokens, as `cw20:<contract>` denom to symbol.
+	GetCw20Denoms() (map[string]string, error)
+
+	// GetCw721ContractInfo returns the information of the CW-721 NFT collection.
+	GetCw721ContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw721Token returns the owner, approvals, token URI and on-chain metadata of the CW-721 NFT.
+	GetCw721Token(contractAddress, tokenId string) (berpctypes.GenericBackendResponse, error)
+
+	// GetCw721Tokens returns the token IDs owned by the account on each of the CW-721 contracts, paginated per contract.
+	GetCw721Tokens(ownerAddress string, contractAddresses []string, startAfters map[string]string, limit int) (berpctypes.GenericBackendResponse, error)
 
 	// Wasm
 
+	// GetCodeInfo returns the information of the stored code by id.
+	GetCodeInfo(codeId uint64) (berpctypes.GenericBackendResponse, error)
+
+	// ListCodes returns the stored codes, paginated. Expensive, the bytecode of each code is downloaded for the size.
+	ListCodes(pageKey string, limit int) (berpctypes.GenericBackendResponse, error)
+
+	// GetContractsByCode returns the contracts those instantiated from the code, paginated.
+	GetContractsByCode(codeId uint64, pageKey string, limit int) (berpctypes.GenericBackendResponse, error)
+
 	SmartContractState(input map[string]any, contract string, optionalBlockNumber *int64) ([]byte, error)
 
+	// GetRawContractState returns the raw value stored under the hex encoded key in the contract storage.
+	GetRawContractState(contractAddress string, keyHex string) (berpctypes.GenericBackendResponse, error)
+
+	// GetAllContractState returns the entries of the contract storage, paginated.
+	GetAllContractState(contractAddress string, pageKey string, limit int) (berpctypes.GenericBackendResponse, error)
+
+	// QuerySmartContract performs the smart query against the contract, with safety limits, for public usage.
+	QuerySmartContract(contractAddress string, query json.RawMessage, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error)
+
 	GetContractCodeId(contractAddress string) (uint64, error)
 
+	// GetContractInfo returns the contract information by address, includes admin, creator, label and IBC port id.
+	GetContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error)
+
+	// GetContractHistory returns the code history of the contract, includes the instantiation and every migration.
+	GetContractHistory(contractAddress string, pageKey string, limit int) (berpctypes.GenericBackendResponse, error)
+
+	// QueryContractInfo returns the contract information by address, nil if not a contract.
+	QueryContractInfo(contractAddress string) (*wasmtypes.ContractInfo, error)
+
 	// Misc
 
 	GetWasmModuleParams() (*wasmtypes.Params, error)
+
+	// GetBaseBackend returns the Block Explorer Json-RPC backend without request interceptor,
+	// nil if not provided yet.
+	GetBaseBackend() berpcbackend.BackendI
 }
 
 // WasmBackend implements the WasmBackendI interface
@@ -51,6 +132,20 @@ type WasmBackend struct {
 	queryClient *iberpctypes.QueryClient // gRPC query client
 	logger      log.Logger
 	cfg         config.BeJsonRpcConfig
+	wasmCfg     iberpccfg.WasmBeJsonRpcConfig
+
+	messageParsers             map[string]berpctypes.MessageParser
+	messageInvolversExtractors map[string]berpctypes.MessageInvolversExtractor
+
+	// baseBackend is the Block Explorer Json-RPC backend without request interceptor,
+	// used to build the base responses those being enriched by the interceptor.
+	baseBackend berpcbackend.BackendI
+
+	// cache
+	cw20DenomsCache         *cw20DenomsCache
+	cw20TokenMetadataCache  *cw20TokenMetadataCache
+	txResultEventsCache     *txResultEventsCache
+	cw20HoldersRankingCache *cw20HoldersRankingCache
 }
 
 // NewWasmBackend creates a new WasmBackend instance for Wasm Block Explorer
@@ -65,11 +160,53 @@ func NewWasmBackend(
 		panic(err)
 	}
 
+	wasmConf, err := iberpccfg.GetConfig(ctx.Viper)
+	if err != nil {
+		panic(err)
+	}
+
 	return &WasmBackend{
 		ctx:         context.Background(),
 		clientCtx:   clientCtx,
 		queryClient: iberpctypes.NewQueryClient(clientCtx),
 		logger:      logger.With("module", "wasm_be_rpc"),
 		cfg:         appConf,
+		wasmCfg:     wasmConf,
+
+		messageParsers:             make(map[string]berpctypes.MessageParser),
+		messageInvolversExtractors: make(map[string]berpctypes.MessageInvolversExtractor),
+
+		// cache
+		cw20DenomsCache:         newCw20DenomsCache(),
+		cw20TokenMetadataCache:  newCw20TokenMetadataCache(wasmConf.Cw20TokenInfoCacheSize, wasmConf.Cw20TokenInfoCacheTTL),
+		txResultEventsCache:     newTxResultEventsCache(txResultEventsCacheSize),
+		cw20HoldersRankingCache: newCw20HoldersRankingCache(cw20HoldersRankingCacheSize, cw20HoldersRankingCacheTTL),
+	}
+}
+
+// WithMessageParsers sets the message parsers those used to decode Wasm messages, keyed by proto message name.
+func (m *WasmBackend) WithMessageParsers(messageParsers map[string]berpctypes.MessageParser) *WasmBackend {
+	for protoType, parser := range messageParsers {
+		m.messageParsers[protoType] = parser
+	}
+	return m
+}
+
+// WithBaseBackend sets the Block Explorer Json-RPC backend without request interceptor.
+func (m *WasmBackend) WithBaseBackend(baseBackend berpcbackend.BackendI) *WasmBackend {
+	m.baseBackend = baseBackend
+	return m
+}
+
+func (m *WasmBackend) GetBaseBackend() berpcbackend.BackendI {
+	return m.baseBackend
+}
+
+// WithMessageInvolversExtractors sets the message involvers extractors those used to extract involvers of Wasm messages,
+// keyed by proto message name.
+func (m *WasmBackend) WithMessageInvolversExtractors(messageInvolversExtractors map[string]berpctypes.MessageInvolversExtractor) *WasmBackend {
+	for protoType, extractor := range messageInvolversExtractors {
+		m.messageInvolversExtractors[protoType] = extractor
 	}
+	return m
 }
@@ -0,0 +1,308 @@
+package wasm
+
+import (
+	"container/list"
+	"errors"
+	iberpctypes "github.com/bcdevtools/wasm-block-explorer-rpc-cosmos/integrate_be_rpc/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// cw20TokenMetadataFailureTTL is the time-to-live of the cached failed lookups,
+// short so transient failures are retried soon.
+const cw20TokenMetadataFailureTTL = 1 * time.Minute
+
+// cw20TokenMetadataCache is a bounded LRU cache of the CW-20 token metadata (name, symbol, decimals),
+// the entries are expired after the TTL.
+// Failed lookups, e.g. non-CW-20 contracts, are cached too, with a shorter TTL.
+type cw20TokenMetadataCache struct {
+	mutex    *sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	lru      *list.List // front is the most recently used
+	now      func() time.Time
+
+	hits   uint64
+	misses uint64
+}
+
+type cw20TokenMetadataCacheEntry struct {
+	contractAddress string
+	metadata        iberpctypes.Cw20TokenInfo
+	errLookup       error // not nil if the lookup failed
+	expiredAt       time.Time
+}
+
+func newCw20TokenMetadataCache(capacity int, ttl time.Duration) *cw20TokenMetadataCache {
+	return &cw20TokenMetadataCache{
+		mutex:    &sync.Mutex{},
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get returns a copy of the cached metadata of the contract, or the cached failure of the lookup,
+// if any and not expired.
+func (c *cw20TokenMetadataCache) Get(contractAddress string) (metadata iberpctypes.Cw20TokenInfo, found bool, errLookup error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.entries[contractAddress]
+	if exists {
+		entry := element.Value.(*cw20TokenMetadataCacheEntry)
+		if c.now().Before(entry.expiredAt) {
+			c.lru.MoveToFront(element)
+			atomic.AddUint64(&c.hits, 1)
+			return entry.metadata, true, entry.errLookup
+		}
+
+		c.removeWithoutLock(element)
+	}
+
+	atomic.AddUint64(&c.misses, 1)
+	return
+}
+
+// Set puts the metadata of the contract into the cache, evicts the least recently used entry if full.
+func (c *cw20TokenMetadataCache) Set(contractAddress string, metadata iberpctypes.Cw20TokenInfo) {
+	c.put(&cw20TokenMetadataCacheEntry{
+		contractAddress: contractAddress,
+		metadata:        metadata,
+		expiredAt:       c.now().Add(c.ttl),
+	})
+}
+
+// SetFailure puts the failure of the lookup of the contract into the cache, expired after a short time.
+func (c *cw20TokenMetadataCache) SetFailure(contractAddress string, errLookup error) {
+	ttl := cw20TokenMetadataFailureTTL
+	if c.ttl < ttl {
+		ttl = c.ttl
+	}
+
+	c.put(&cw20TokenMetadataCacheEntry{
+		contractAddress: contractAddress,
+		errLookup:       errLookup,
+		expiredAt:       c.now().Add(ttl),
+	})
+}
+
+func (c *cw20TokenMetadataCache) put(entry *cw20TokenMetadataCacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.entries[entry.contractAddress]; exists {
+		c.removeWithoutLock(element)
+	}
+
+	c.entries[entry.contractAddress] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.capacity {
+		c.removeWithoutLock(c.lru.Back())
+	}
+}
+
+// Invalidate removes the cached metadata of the contract, if any.
+func (c *cw20TokenMetadataCache) Invalidate(contractAddress string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.entries[contractAddress]; exists {
+		c.removeWithoutLock(element)
+	}
+}
+
+// Stats returns the number of cache hits & misses.
+func (c *cw20TokenMetadataCache) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
+
+// removeWithoutLock removes the element. Lock acquire must be performed before calling this.
+func (c *cw20TokenMetadataCache) removeWithoutLock(element *list.Element) {
+	entry := element.Value.(*cw20TokenMetadataCacheEntry)
+	delete(c.entries, entry.contractAddress)
+	c.lru.Remove(element)
+}
+
+// txResultEventsCacheSize is the maximum number of tx results to be cached,
+// enough for the extractors of the recently queried txs.
+const txResultEventsCacheSize = 128
+
+// txResultEventsCache is a bounded cache of the tx result events, keyed by tx hash.
+// The tx results are immutable, so entries are only evicted by the capacity, oldest first.
+type txResultEventsCache struct {
+	mutex    *sync.Mutex
+	capacity int
+	entries  map[string][]abci.Event
+	order    *list.List // front is the oldest
+}
+
+func newTxResultEventsCache(capacity int) *txResultEventsCache {
+	return &txResultEventsCache{
+		mutex:    &sync.Mutex{},
+		capacity: capacity,
+		entries:  make(map[string][]abci.Event),
+		order:    list.New(),
+	}
+}
+
+// Get returns the cached events of the tx, if any.
+func (c *txResultEventsCache) Get(txHash []byte) (events []abci.Event, found bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	events, found = c.entries[string(txHash)]
+	return
+}
+
+// Set puts the events of the tx into the cache, evicts the oldest entry if full.
+func (c *txResultEventsCache) Set(txHash []byte, events []abci.Event) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := string(txHash)
+	if _, exists := c.entries[key]; exists {
+		return
+	}
+
+	c.entries[key] = events
+	c.order.PushBack(key)
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Front()
+		delete(c.entries, oldest.Value.(string))
+		c.order.Remove(oldest)
+	}
+}
+
+const (
+	// cw20HoldersRankingCacheSize is the maximum number of CW-20 holders rankings to be cached.
+	cw20HoldersRankingCacheSize = 16
+
+	// cw20HoldersRankingCacheTTL is the time-to-live of the cached CW-20 holders rankings,
+	// short so the ranking does not go far behind the balances.
+	cw20HoldersRankingCacheTTL = 30 * time.Second
+)
+
+// cw20HoldersRankingCache is a bounded cache of the CW-20 holders ranked by balance, keyed by contract.
+// Concurrent lookups of the same contract share a single computation,
+// so the holders of a contract are enumerated at most once per TTL. Failures are not cached.
+type cw20HoldersRankingCache struct {
+	mutex    *sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*cw20HoldersRankingCacheEntry
+	now      func() time.Time
+}
+
+type cw20HoldersRankingCacheEntry struct {
+	ready     chan struct{} // closed when the computation completed, the fields below are set before closing
+	ranked    []cw20Holder
+	err       error
+	expiredAt time.Time
+}
+
+func newCw20HoldersRankingCache(capacity int, ttl time.Duration) *cw20HoldersRankingCache {
+	return &cw20HoldersRankingCache{
+		mutex:    &sync.Mutex{},
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*cw20HoldersRankingCacheEntry),
+		now:      time.Now,
+	}
+}
+
+// GetOrCompute returns the cached ranking of the contract, or waits for the in-progress computation.
+// Otherwise, the ranking is computed and cached.
+// The returned ranking is shared, it must not be modified.
+func (c *cw20HoldersRankingCache) GetOrCompute(contractAddress string, compute func() ([]cw20Holder, error)) ([]cw20Holder, error) {
+	c.mutex.Lock()
+
+	entry, exists := c.entries[contractAddress]
+	if exists && c.isExpiredWithoutLock(entry) {
+		delete(c.entries, contractAddress)
+		exists = false
+	}
+
+	if exists {
+		c.mutex.Unlock()
+
+		<-entry.ready
+		return entry.ranked, entry.err
+	}
+
+	entry = &cw20HoldersRankingCacheEntry{
+		ready: make(chan struct{}),
+	}
+	c.entries[contractAddress] = entry
+	c.evictWithoutLock()
+
+	c.mutex.Unlock()
+
+	func() {
+		defer close(entry.ready)
+
+		entry.err = errors.New("failed to rank holders") // kept if the computation panics
+		entry.ranked, entry.err = compute()
+		entry.expiredAt = c.now().Add(c.ttl)
+	}()
+
+	if entry.err != nil {
+		c.mutex.Lock()
+		if c.entries[contractAddress] == entry {
+			delete(c.entries, contractAddress)
+		}
+		c.mutex.Unlock()
+	}
+
+	return entry.ranked, entry.err
+}
+
+// isExpiredWithoutLock returns true if the computation completed and the result is expired.
+// Lock acquire must be performed before calling this.
+func (c *cw20HoldersRankingCache) isExpiredWithoutLock(entry *cw20HoldersRankingCacheEntry) bool {
+	select {
+	case <-entry.ready:
+		return !c.now().Before(entry.expiredAt)
+	default:
+		return false // in progress
+	}
+}
+
+// evictWithoutLock removes the expired entries, then the earliest expiring completed entries, while over capacity.
+// In-progress entries are never evicted. Lock acquire must be performed before calling this.
+func (c *cw20HoldersRankingCache) evictWithoutLock() {
+	if len(c.entries) <= c.capacity {
+		return
+	}
+
+	for contractAddress, entry := range c.entries {
+		if c.isExpiredWithoutLock(entry) {
+			delete(c.entries, contractAddress)
+		}
+	}
+
+	for len(c.entries) > c.capacity {
+		var earliestContractAddress string
+		var earliest *cw20HoldersRankingCacheEntry
+		for contractAddress, entry := range c.entries {
+			select {
+			case <-entry.ready:
+			default:
+				continue // in progress
+			}
+			if earliest == nil || entry.expiredAt.Before(earliest.expiredAt) {
+				earliestContractAddress, earliest = contractAddress, entry
+			}
+		}
+		if earliest == nil {
+			return
+		}
+		delete(c.entries, earliestContractAddress)
+	}
+}
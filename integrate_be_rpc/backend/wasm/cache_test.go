@@ -0,0 +1,277 @@
+package wasm
+
+import (
+	"fmt"
+	iberpctypes "github.com/bcdevtools/wasm-block-explorer-rpc-cosmos/integrate_be_rpc/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_txResultEventsCache(t *testing.T) {
+	cache := newTxResultEventsCache(2)
+
+	events := func(eventType string) []abci.Event {
+		return []abci.Event{{Type: eventType}}
+	}
+
+	_, found := cache.Get([]byte("tx1"))
+	require.False(t, found)
+
+	cache.Set([]byte("tx1"), events("e1"))
+	cache.Set([]byte("tx2"), events("e2"))
+
+	got, found := cache.Get([]byte("tx1"))
+	require.True(t, found)
+	require.Equal(t, events("e1"), got)
+
+	// re-set does not override nor refresh the entry
+	cache.Set([]byte("tx1"), events("e1-modified"))
+	got, found = cache.Get([]byte("tx1"))
+	require.True(t, found)
+	require.Equal(t, events("e1"), got)
+
+	// the oldest is evicted when full
+	cache.Set([]byte("tx3"), events("e3"))
+	_, found = cache.Get([]byte("tx1"))
+	require.False(t, found)
+
+	for i := 2; i <= 3; i++ {
+		got, found := cache.Get([]byte(fmt.Sprintf("tx%d", i)))
+		require.True(t, found)
+		require.Equal(t, events(fmt.Sprintf("e%d", i)), got)
+	}
+}
+
+func newTestCw20TokenMetadataCache(capacity int, ttl time.Duration) (*cw20TokenMetadataCache, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newCw20TokenMetadataCache(capacity, ttl)
+	cache.now = func() time.Time {
+		return now
+	}
+	return cache, &now
+}
+
+func Test_cw20TokenMetadataCache_GetSet(t *testing.T) {
+	cache, _ := newTestCw20TokenMetadataCache(10, time.Hour)
+
+	_, found, _ := cache.Get("c1")
+	require.False(t, found)
+
+	cache.Set("c1", iberpctypes.Cw20TokenInfo{Name: "Token", Symbol: "TKN", Decimals: 6})
+
+	got, found, errLookup := cache.Get("c1")
+	require.True(t, found)
+	require.NoError(t, errLookup)
+	require.Equal(t, iberpctypes.Cw20TokenInfo{Name: "Token", Symbol: "TKN", Decimals: 6}, got)
+
+	// override
+	cache.Set("c1", iberpctypes.Cw20TokenInfo{Name: "Token", Symbol: "TKN2", Decimals: 6})
+	got, found, _ = cache.Get("c1")
+	require.True(t, found)
+	require.Equal(t, "TKN2", got.Symbol)
+
+	hits, misses := cache.Stats()
+	require.Equal(t, uint64(2), hits)
+	require.Equal(t, uint64(1), misses)
+}
+
+func Test_cw20TokenMetadataCache_EvictLeastRecentlyUsed(t *testing.T) {
+	cache, _ := newTestCw20TokenMetadataCache(2, time.Hour)
+
+	cache.Set("c1", iberpctypes.Cw20TokenInfo{Symbol: "T1"})
+	cache.Set("c2", iberpctypes.Cw20TokenInfo{Symbol: "T2"})
+
+	// c1 becomes the most recently used
+	_, found, _ := cache.Get("c1")
+	require.True(t, found)
+
+	cache.Set("c3", iberpctypes.Cw20TokenInfo{Symbol: "T3"})
+
+	_, found, _ = cache.Get("c2")
+	require.False(t, found, "least recently used should be evicted")
+
+	for _, contract := range []string{"c1", "c3"} {
+		_, found, _ = cache.Get(contract)
+		require.True(t, found, contract)
+	}
+
+	require.Equal(t, 2, cache.lru.Len())
+	require.Len(t, cache.entries, 2)
+}
+
+func Test_cw20TokenMetadataCache_Expiry(t *testing.T) {
+	cache, now := newTestCw20TokenMetadataCache(10, time.Hour)
+
+	cache.Set("c1", iberpctypes.Cw20TokenInfo{Symbol: "T1"})
+
+	*now = now.Add(time.Hour - time.Second)
+	_, found, _ := cache.Get("c1")
+	require.True(t, found)
+
+	*now = now.Add(time.Second)
+	_, found, _ = cache.Get("c1")
+	require.False(t, found)
+
+	// expired entry is removed
+	require.Zero(t, cache.lru.Len())
+	require.Empty(t, cache.entries)
+}
+
+func Test_cw20TokenMetadataCache_Failure(t *testing.T) {
+	cache, now := newTestCw20TokenMetadataCache(10, time.Hour)
+
+	errNotCw20 := fmt.Errorf("not a CW-20 contract")
+	cache.SetFailure("c1", errNotCw20)
+
+	_, found, errLookup := cache.Get("c1")
+	require.True(t, found)
+	require.Equal(t, errNotCw20, errLookup)
+
+	// failure expires sooner than the metadata TTL
+	*now = now.Add(cw20TokenMetadataFailureTTL)
+	_, found, _ = cache.Get("c1")
+	require.False(t, found)
+
+	// failure TTL does not exceed the metadata TTL
+	shortCache, shortNow := newTestCw20TokenMetadataCache(10, time.Second)
+	shortCache.SetFailure("c1", errNotCw20)
+	*shortNow = shortNow.Add(time.Second)
+	_, found, _ = shortCache.Get("c1")
+	require.False(t, found)
+
+	// success overrides the failure
+	cache.SetFailure("c2", errNotCw20)
+	cache.Set("c2", iberpctypes.Cw20TokenInfo{Symbol: "T2"})
+	got, found, errLookup := cache.Get("c2")
+	require.True(t, found)
+	require.NoError(t, errLookup)
+	require.Equal(t, "T2", got.Symbol)
+}
+
+func Test_cw20TokenMetadataCache_Invalidate(t *testing.T) {
+	cache, _ := newTestCw20TokenMetadataCache(10, time.Hour)
+
+	cache.Set("c1", iberpctypes.Cw20TokenInfo{Symbol: "T1"})
+	cache.Set("c2", iberpctypes.Cw20TokenInfo{Symbol: "T2"})
+	cache.SetFailure("c3", fmt.Errorf("failed"))
+
+	cache.Invalidate("c1")
+	cache.Invalidate("c3")
+	cache.Invalidate("c4") // not cached, no-op
+
+	_, found, _ := cache.Get("c1")
+	require.False(t, found)
+	_, found, _ = cache.Get("c3")
+	require.False(t, found)
+	_, found, _ = cache.Get("c2")
+	require.True(t, found)
+
+	require.Equal(t, 1, cache.lru.Len())
+}
+
+func newTestCw20HoldersRankingCache(capacity int, ttl time.Duration) (*cw20HoldersRankingCache, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newCw20HoldersRankingCache(capacity, ttl)
+	cache.now = func() time.Time {
+		return now
+	}
+	return cache, &now
+}
+
+func Test_cw20HoldersRankingCache_GetOrCompute(t *testing.T) {
+	cache, now := newTestCw20HoldersRankingCache(10, time.Minute)
+
+	var computed int
+	compute := func() ([]cw20Holder, error) {
+		computed++
+		return []cw20Holder{{account: fmt.Sprintf("a%d", computed)}}, nil
+	}
+
+	ranked, err := cache.GetOrCompute("c1", compute)
+	require.NoError(t, err)
+	require.Equal(t, "a1", ranked[0].account)
+
+	// served from the cache
+	ranked, err = cache.GetOrCompute("c1", compute)
+	require.NoError(t, err)
+	require.Equal(t, "a1", ranked[0].account)
+	require.Equal(t, 1, computed)
+
+	// re-computed after expired
+	*now = now.Add(time.Minute)
+	ranked, err = cache.GetOrCompute("c1", compute)
+	require.NoError(t, err)
+	require.Equal(t, "a2", ranked[0].account)
+	require.Equal(t, 2, computed)
+}
+
+func Test_cw20HoldersRankingCache_FailureNotCached(t *testing.T) {
+	cache, _ := newTestCw20HoldersRankingCache(10, time.Minute)
+
+	_, err := cache.GetOrCompute("c1", func() ([]cw20Holder, error) {
+		return nil, fmt.Errorf("failed")
+	})
+	require.Error(t, err)
+	require.Empty(t, cache.entries)
+
+	ranked, err := cache.GetOrCompute("c1", func() ([]cw20Holder, error) {
+		return []cw20Holder{{account: "a1"}}, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, ranked, 1)
+}
+
+func Test_cw20HoldersRankingCache_ShareInProgressComputation(t *testing.T) {
+	cache, _ := newTestCw20HoldersRankingCache(10, time.Minute)
+
+	var computed int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	compute := func() ([]cw20Holder, error) {
+		atomic.AddInt32(&computed, 1)
+		close(started)
+		<-release
+		return []cw20Holder{{account: "a1"}}, nil
+	}
+
+	done := make(chan []cw20Holder, 2)
+	go func() {
+		ranked, _ := cache.GetOrCompute("c1", compute)
+		done <- ranked
+	}()
+	<-started
+	go func() {
+		ranked, _ := cache.GetOrCompute("c1", compute)
+		done <- ranked
+	}()
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		ranked := <-done
+		require.Len(t, ranked, 1)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&computed))
+}
+
+func Test_cw20HoldersRankingCache_Evict(t *testing.T) {
+	cache, now := newTestCw20HoldersRankingCache(2, time.Minute)
+
+	compute := func() ([]cw20Holder, error) {
+		return []cw20Holder{}, nil
+	}
+
+	_, _ = cache.GetOrCompute("c1", compute)
+	*now = now.Add(time.Second)
+	_, _ = cache.GetOrCompute("c2", compute)
+	*now = now.Add(time.Second)
+	_, _ = cache.GetOrCompute("c3", compute)
+
+	// the earliest expiring is evicted
+	require.Len(t, cache.entries, 2)
+	require.NotContains(t, cache.entries, "c1")
+	require.Contains(t, cache.entries, "c2")
+	require.Contains(t, cache.entries, "c3")
+}
@@ -0,0 +1,165 @@
+package wasm
+
+import (
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// GetCodeInfo will return information of the stored code by id.
+//   - codeId: the id of the code.
+//   - creator: the address who stored the code.
+//   - checksum: the hex-encoded data hash of the Wasm bytecode.
+//   - instantiatePermission: who can instantiate contracts from the code.
+//   - size: the byte size of the Wasm bytecode.
+func (m *WasmBackend) GetCodeInfo(codeId uint64) (berpctypes.GenericBackendResponse, error) {
+	if codeId == 0 {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	resCode, err := m.queryClient.WasmQueryClient.Code(m.ctx, &wasmtypes.QueryCodeRequest{
+		CodeId: codeId,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, errors.Wrap(err, "code not found").Error())
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get code").Error())
+	}
+	if resCode.CodeInfoResponse == nil {
+		return nil, status.Error(codes.NotFound, "code not found")
+	}
+
+	res := buildCodeInfoResponse(*resCode.CodeInfoResponse)
+	res["size"] = len(resCode.Data)
+
+	return res, nil
+}
+
+const (
+	defaultListCodesPageSize = 5
+	maxListCodesPageSize     = 10
+)
+
+// ListCodes returns the stored codes, paginated. The information of each code is the same as GetCodeInfo.
+//
+// To provide the size, the whole bytecode of each code is downloaded from the node, which can be up to a few MB each,
+// so the page size is much smaller than other paginated queries.
+func (m *WasmBackend) ListCodes(pageKey string, limit int) (berpctypes.GenericBackendResponse, error) {
+	if limit < 0 || limit > maxListCodesPageSize {
+		return nil, berpctypes.ErrBadPageSize
+	}
+	if limit == 0 {
+		limit = defaultListCodesPageSize
+	}
+
+	pagination, err := getPaginationByKey(pageKey, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resCodes, err := m.queryClient.WasmQueryClient.Codes(m.ctx, &wasmtypes.QueryCodesRequest{
+		Pagination: pagination,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to query codes").Error())
+	}
+
+	resForCodes := make([]berpctypes.GenericBackendResponse, 0)
+	for _, codeInfo := range resCodes.CodeInfos {
+		resCode := buildCodeInfoResponse(codeInfo)
+
+		// the list query does not provide the bytecode, so query each code for the size
+		resCodeWithData, err := m.queryClient.WasmQueryClient.Code(m.ctx, &wasmtypes.QueryCodeRequest{
+			CodeId: codeInfo.CodeID,
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get code").Error())
+		}
+		resCode["size"] = len(resCodeWithData.Data)
+
+		resForCodes = append(resForCodes, resCode)
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"codes": resForCodes,
+	}
+
+	putNextPageKey(res, resCodes.Pagination)
+
+	return res, nil
+}
+
+func buildCodeInfoResponse(codeInfo wasmtypes.CodeInfoResponse) berpctypes.GenericBackendResponse {
+	instantiatePermission := map[string]any{
+		"permission": codeInfo.InstantiatePermission.Permission.String(),
+	}
+	if len(codeInfo.InstantiatePermission.Addresses) > 0 {
+		instantiatePermission["addresses"] = codeInfo.InstantiatePermission.Addresses
+	} else if len(codeInfo.InstantiatePermission.Address) > 0 {
+		instantiatePermission["addresses"] = []string{codeInfo.InstantiatePermission.Address}
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"codeId":                codeInfo.CodeID,
+		"creator":               codeInfo.Creator,
+		"checksum":              strings.ToLower(codeInfo.DataHash.String()),
+		"instantiatePermission": instantiatePermission,
+	}
+}
+
+// GetContractsByCode returns the contracts those instantiated from the code, paginated.
+// Each contract comes with its label and admin, if any.
+func (m *WasmBackend) GetContractsByCode(codeId uint64, pageKey string, limit int) (berpctypes.GenericBackendResponse, error) {
+	if codeId == 0 {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	pagination, err := getPaginationByKey(pageKey, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resContracts, err := m.queryClient.WasmQueryClient.ContractsByCode(m.ctx, &wasmtypes.QueryContractsByCodeRequest{
+		CodeId:     codeId,
+		Pagination: pagination,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to query contracts by code").Error())
+	}
+
+	resForContracts := make([]berpctypes.GenericBackendResponse, 0)
+	for _, contractAddress := range resContracts.Contracts {
+		contractInfo, err := m.QueryContractInfo(contractAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		resContract := berpctypes.GenericBackendResponse{
+			"contract": contractAddress,
+		}
+
+		if contractInfo != nil {
+			if len(contractInfo.Label) > 0 {
+				resContract["label"] = contractInfo.Label
+			}
+			if len(contractInfo.Admin) > 0 {
+				resContract["admin"] = contractInfo.Admin
+			}
+		}
+
+		resForContracts = append(resForContracts, resContract)
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"codeId":    codeId,
+		"contracts": resForContracts,
+	}
+
+	putNextPageKey(res, resContracts.Pagination)
+
+	return res, nil
+}
@@ -1,22 +1,147 @@
 package wasm
 
 import (
+	"encoding/json"
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"strings"
 )
 
-func (m *WasmBackend) GetContractCodeId(contractAddress string) (uint64, error) {
+// QueryContractInfo returns the contract information by address.
+// If the address is not a contract, it returns nil without error.
+func (m *WasmBackend) QueryContractInfo(contractAddress string) (*wasmtypes.ContractInfo, error) {
 	resContractInfo, err := m.queryClient.WasmQueryClient.ContractInfo(m.ctx, &wasmtypes.QueryContractInfoRequest{
 		Address: contractAddress,
 	})
 	if err != nil {
 		if strings.Contains(err.Error(), "no such contract") {
-			return 0, nil
+			return nil, nil
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract info").Error())
+	}
+	return &resContractInfo.ContractInfo, nil
+}
+
+func (m *WasmBackend) GetContractCodeId(contractAddress string) (uint64, error) {
+	contractInfo, err := m.QueryContractInfo(contractAddress)
+	if err != nil {
+		return 0, err
+	}
+	if contractInfo == nil {
+		return 0, nil
+	}
+	return contractInfo.CodeID, nil
+}
+
+// GetContractInfo will return information of the contract by address.
+//   - codeId: the code id of the contract.
+//   - creator: the address who instantiated the contract.
+//   - admin: the address that can execute migrations, optional.
+//   - label: the label of the contract, optional.
+//   - ibcPortId: the IBC port id of the contract, optional.
+//   - created: the absolute tx position when the contract was instantiated.
+func (m *WasmBackend) GetContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error) {
+	contractInfo, err := m.QueryContractInfo(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if contractInfo == nil {
+		return nil, status.Error(codes.NotFound, errors.New(contractAddress+" is not a contract").Error())
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"codeId":   contractInfo.CodeID,
+		"creator":  contractInfo.Creator,
+	}
+
+	if len(contractInfo.Admin) > 0 {
+		res["admin"] = contractInfo.Admin
+	}
+
+	if len(contractInfo.Label) > 0 {
+		res["label"] = contractInfo.Label
+	}
+
+	if len(contractInfo.IBCPortID) > 0 {
+		res["ibcPortId"] = contractInfo.IBCPortID
+	}
+
+	if contractInfo.Created != nil {
+		res["created"] = berpctypes.GenericBackendResponse{
+			"blockHeight": contractInfo.Created.BlockHeight,
+			"txIndex":     contractInfo.Created.TxIndex,
+		}
+	}
+
+	return res, nil
+}
+
+// GetContractHistory returns the code history of the contract, includes the instantiation and every migration.
+func (m *WasmBackend) GetContractHistory(contractAddress string, pageKey string, limit int) (berpctypes.GenericBackendResponse, error) {
+	pagination, err := getPaginationByKey(pageKey, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resHistory, err := m.queryClient.WasmQueryClient.ContractHistory(m.ctx, &wasmtypes.QueryContractHistoryRequest{
+		Address:    contractAddress,
+		Pagination: pagination,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "no such contract") {
+			return nil, status.Error(codes.NotFound, errors.New(contractAddress+" is not a contract").Error())
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract history").Error())
+	}
+
+	entries := make([]berpctypes.GenericBackendResponse, 0)
+	for _, entry := range resHistory.Entries {
+		resEntry := berpctypes.GenericBackendResponse{
+			"operation": getContractCodeHistoryOperationName(entry.Operation),
+			"codeId":    entry.CodeID,
 		}
-		return 0, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract info").Error())
+
+		if entry.Updated != nil {
+			resEntry["updated"] = berpctypes.GenericBackendResponse{
+				"blockHeight": entry.Updated.BlockHeight,
+				"txIndex":     entry.Updated.TxIndex,
+			}
+		}
+
+		if len(entry.Msg) > 0 {
+			var unmarshalledMsg any
+			if err := json.Unmarshal(entry.Msg, &unmarshalledMsg); err == nil {
+				resEntry["msg"] = unmarshalledMsg
+			}
+		}
+
+		entries = append(entries, resEntry)
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"entries":  entries,
+	}
+
+	putNextPageKey(res, resHistory.Pagination)
+
+	return res, nil
+}
+
+func getContractCodeHistoryOperationName(operation wasmtypes.ContractCodeHistoryOperationType) string {
+	switch operation {
+	case wasmtypes.ContractCodeHistoryOperationTypeInit:
+		return "init"
+	case wasmtypes.ContractCodeHistoryOperationTypeMigrate:
+		return "migrate"
+	case wasmtypes.ContractCodeHistoryOperationTypeGenesis:
+		return "genesis"
+	default:
+		return "unspecified"
 	}
-	return resContractInfo.CodeID, nil
 }
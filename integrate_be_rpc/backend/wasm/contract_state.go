@@ -0,0 +1,163 @@
+package wasm
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GetRawContractState returns the raw value stored under the key in the contract storage.
+// The key is hex encoded, with or without 0x prefix.
+func (m *WasmBackend) GetRawContractState(contractAddress string, keyHex string) (berpctypes.GenericBackendResponse, error) {
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(keyHex), "0x"))
+	if err != nil || len(key) < 1 {
+		return nil, status.Error(codes.InvalidArgument, "key must be non-empty hex")
+	}
+
+	resState, err := m.queryClient.WasmQueryClient.RawContractState(m.ctx, &wasmtypes.QueryRawContractStateRequest{
+		Address:   contractAddress,
+		QueryData: key,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "no such contract") {
+			return nil, status.Error(codes.NotFound, errors.New(contractAddress+" is not a contract").Error())
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get raw contract state").Error())
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"key":      decodeContractStateKey(key),
+	}
+
+	if resState.Data != nil {
+		res["value"] = decodeContractStateBytes(resState.Data)
+	}
+
+	return res, nil
+}
+
+// GetAllContractState returns the entries of the contract storage, paginated.
+func (m *WasmBackend) GetAllContractState(contractAddress string, pageKey string, limit int) (berpctypes.GenericBackendResponse, error) {
+	pagination, err := getPaginationByKey(pageKey, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resState, err := m.queryClient.WasmQueryClient.AllContractState(m.ctx, &wasmtypes.QueryAllContractStateRequest{
+		Address:    contractAddress,
+		Pagination: pagination,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "no such contract") {
+			return nil, status.Error(codes.NotFound, errors.New(contractAddress+" is not a contract").Error())
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get all contract state").Error())
+	}
+
+	entries := make([]berpctypes.GenericBackendResponse, 0)
+	for _, model := range resState.Models {
+		entries = append(entries, berpctypes.GenericBackendResponse{
+			"key":   decodeContractStateKey(model.Key),
+			"value": decodeContractStateBytes(model.Value),
+		})
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"entries":  entries,
+	}
+
+	putNextPageKey(res, resState.Pagination)
+
+	return res, nil
+}
+
+// decodeContractStateBytes returns the hex and base64 representations of the bytes,
+// plus the best-effort UTF-8 and JSON decoded.
+func decodeContractStateBytes(bz []byte) berpctypes.GenericBackendResponse {
+	res := berpctypes.GenericBackendResponse{
+		"hex":    hex.EncodeToString(bz),
+		"base64": base64.StdEncoding.EncodeToString(bz),
+	}
+
+	if isPrintableUtf8(bz) {
+		res["utf8"] = string(bz)
+
+		var decoded any
+		if err := json.Unmarshal(bz, &decoded); err == nil {
+			res["json"] = decoded
+		}
+	}
+
+	return res
+}
+
+// decodeContractStateKey decodes the key like decodeContractStateBytes,
+// plus splits the key into namespaces if it follows the cw-storage-plus length-prefixed convention.
+func decodeContractStateKey(key []byte) berpctypes.GenericBackendResponse {
+	res := decodeContractStateBytes(key)
+
+	namespaces, suffix := splitCwStoragePlusKey(key)
+	if len(namespaces) > 0 {
+		decodedNamespaces := make([]berpctypes.GenericBackendResponse, 0, len(namespaces))
+		for _, namespace := range namespaces {
+			decodedNamespaces = append(decodedNamespaces, decodeContractStateBytes(namespace))
+		}
+		res["namespaces"] = decodedNamespaces
+		res["suffix"] = decodeContractStateBytes(suffix)
+	}
+
+	return res
+}
+
+// splitCwStoragePlusKey splits the key into the length-prefixed namespaces and the remaining suffix,
+// following the cw-storage-plus convention: `len(ns1) | ns1 | len(ns2) | ns2 | ... | suffix`,
+// where each length is 2 bytes big-endian.
+// The first namespace must be a printable UTF-8 string, otherwise the key is considered not following the convention.
+func splitCwStoragePlusKey(key []byte) (namespaces [][]byte, suffix []byte) {
+	remaining := key
+	for len(remaining) > 2 {
+		size := int(binary.BigEndian.Uint16(remaining[:2]))
+		if size < 1 || 2+size >= len(remaining) {
+			break
+		}
+
+		namespace := remaining[2 : 2+size]
+		if len(namespaces) == 0 && !isPrintableUtf8(namespace) {
+			break
+		}
+
+		namespaces = append(namespaces, namespace)
+		remaining = remaining[2+size:]
+	}
+
+	if len(namespaces) == 0 {
+		return nil, key
+	}
+
+	return namespaces, remaining
+}
+
+func isPrintableUtf8(bz []byte) bool {
+	if len(bz) < 1 || !utf8.Valid(bz) {
+		return false
+	}
+
+	for _, r := range string(bz) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package wasm
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_splitCwStoragePlusKey(t *testing.T) {
+	testcases := []struct {
+		name           string
+		key            []byte
+		wantNamespaces [][]byte
+		wantSuffix     []byte
+	}{
+		{
+			name:           "empty key",
+			key:            []byte{},
+			wantNamespaces: nil,
+			wantSuffix:     []byte{},
+		},
+		{
+			name:           "plain Item key, not length-prefixed",
+			key:            []byte("token_info"),
+			wantNamespaces: nil,
+			wantSuffix:     []byte("token_info"),
+		},
+		{
+			name:           "Map key with one namespace",
+			key:            append([]byte{0, 7}, []byte("balanceaddr1")...),
+			wantNamespaces: [][]byte{[]byte("balance")},
+			wantSuffix:     []byte("addr1"),
+		},
+		{
+			name: "Map key with composite keys",
+			key: append(append([]byte{0, 9}, []byte("allowance")...),
+				append(append([]byte{0, 5}, []byte("owner")...), []byte("spender")...)...),
+			wantNamespaces: [][]byte{[]byte("allowance"), []byte("owner")},
+			wantSuffix:     []byte("spender"),
+		},
+		{
+			name:           "namespace without suffix is not considered a namespace",
+			key:            append([]byte{0, 7}, []byte("balance")...),
+			wantNamespaces: nil,
+			wantSuffix:     append([]byte{0, 7}, []byte("balance")...),
+		},
+		{
+			name:           "length prefix exceeds the key",
+			key:            append([]byte{0, 100}, []byte("balance")...),
+			wantNamespaces: nil,
+			wantSuffix:     append([]byte{0, 100}, []byte("balance")...),
+		},
+		{
+			name:           "zero length prefix",
+			key:            append([]byte{0, 0}, []byte("balance")...),
+			wantNamespaces: nil,
+			wantSuffix:     append([]byte{0, 0}, []byte("balance")...),
+		},
+		{
+			name:           "first namespace is not printable",
+			key:            []byte{0, 2, 0xff, 0xfe, 1, 2, 3},
+			wantNamespaces: nil,
+			wantSuffix:     []byte{0, 2, 0xff, 0xfe, 1, 2, 3},
+		},
+		{
+			name:           "binary suffix",
+			key:            append(append([]byte{0, 6}, []byte("tokens")...), 0xff, 0x01),
+			wantNamespaces: [][]byte{[]byte("tokens")},
+			wantSuffix:     []byte{0xff, 0x01},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotNamespaces, gotSuffix := splitCwStoragePlusKey(tc.key)
+			require.Equal(t, tc.wantNamespaces, gotNamespaces)
+			require.Equal(t, tc.wantSuffix, gotSuffix)
+		})
+	}
+}
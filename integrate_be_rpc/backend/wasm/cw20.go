@@ -1,14 +1,16 @@
 package wasm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
-	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
 	iberpctypes "github.com/bcdevtools/wasm-block-explorer-rpc-cosmos/integrate_be_rpc/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"math/big"
 )
@@ -18,11 +20,14 @@ import (
 //   - symbol: the symbol of the CW-20 token.
 //   - decimals: the number of decimals the token uses.
 //   - totalSupply: the total supply of the token.
+//   - minter: the minter address and the optional cap, optional.
+//   - marketingInfo: the project, description, marketing address and logo URL, optional.
 //
 // If failed to query any of the mandatory fields, it will return an error.
 // If failed to query the optional field, it will continue.
-func (m *WasmBackend) GetCw20ContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error) {
-	tokenInfo, err := m.GetCw20TokenInfo(contractAddress)
+// If the optional block number is provided, the information is queried at that height.
+func (m *WasmBackend) GetCw20ContractInfo(contractAddress string, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error) {
+	tokenInfo, err := m.getCw20TokenInfoWithOptionalFields(contractAddress, optionalBlockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -37,12 +42,123 @@ func (m *WasmBackend) GetCw20ContractInfo(contractAddress string) (berpctypes.Ge
 		res["totalSupply"] = tokenInfo.TotalSupply.String()
 	}
 
+	if minter := tokenInfo.Minter; minter != nil {
+		resMinter := berpctypes.GenericBackendResponse{
+			"minter": minter.Minter,
+		}
+		if minter.Cap != nil {
+			resMinter["cap"] = *minter.Cap
+		}
+		res["minter"] = resMinter
+	}
+
+	if marketingInfo := tokenInfo.MarketingInfo; marketingInfo != nil {
+		resMarketingInfo := berpctypes.GenericBackendResponse{}
+		if len(marketingInfo.Project) > 0 {
+			resMarketingInfo["project"] = marketingInfo.Project
+		}
+		if len(marketingInfo.Description) > 0 {
+			resMarketingInfo["description"] = marketingInfo.Description
+		}
+		if len(marketingInfo.Marketing) > 0 {
+			resMarketingInfo["marketing"] = marketingInfo.Marketing
+		}
+		if marketingInfo.Logo != nil && len(marketingInfo.Logo.Url) > 0 {
+			resMarketingInfo["logoUrl"] = marketingInfo.Logo.Url
+		}
+		if len(resMarketingInfo) > 0 {
+			res["marketingInfo"] = resMarketingInfo
+		}
+	}
+
 	return res, nil
 }
 
-func (m *WasmBackend) GetCw20Balance(accountAddress string, contractAddresses []string) (berpctypes.GenericBackendResponse, error) {
-	const maxContractsPerQuery = 50
-	if len(contractAddresses) > maxContractsPerQuery {
+// getCw20TokenInfoWithOptionalFields returns the token info, plus the optional minter and marketing info.
+// Failure on querying the optional fields is tolerated.
+func (m *WasmBackend) getCw20TokenInfoWithOptionalFields(contractAddress string, optionalBlockNumber *int64) (*iberpctypes.Cw20TokenInfo, error) {
+	tokenInfo, err := m.queryCw20TokenInfo(contractAddress, optionalBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	res := *tokenInfo // copy, prevent modifying the shared instance
+
+	if minter, err := m.queryCw20Minter(contractAddress, optionalBlockNumber); err == nil {
+		res.Minter = minter
+	}
+
+	if marketingInfo, err := m.queryCw20MarketingInfo(contractAddress, optionalBlockNumber); err == nil {
+		res.MarketingInfo = marketingInfo
+	}
+
+	return &res, nil
+}
+
+// queryCw20Minter returns the minter of the CW-20 contract, nil if the token is not mintable.
+func (m *WasmBackend) queryCw20Minter(contractAddress string, optionalBlockNumber *int64) (*iberpctypes.Cw20Minter, error) {
+	state, err := m.SmartContractState(map[string]any{
+		"minter": map[string]any{},
+	}, contractAddress, optionalBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var data *iberpctypes.Cw20Minter
+	if err := json.Unmarshal(state, &data); err != nil {
+		return nil, err
+	}
+
+	if data == nil || len(data.Minter) < 1 {
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+// queryCw20MarketingInfo returns the marketing info of the CW-20 contract.
+// The logo is provided only if it is an URL.
+func (m *WasmBackend) queryCw20MarketingInfo(contractAddress string, optionalBlockNumber *int64) (*iberpctypes.Cw20MarketingInfo, error) {
+	state, err := m.SmartContractState(map[string]any{
+		"marketing_info": map[string]any{},
+	}, contractAddress, optionalBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Project     string          `json:"project"`
+		Description string          `json:"description"`
+		Marketing   string          `json:"marketing"`
+		Logo        json.RawMessage `json:"logo"`
+	}
+	if err := json.Unmarshal(state, &data); err != nil {
+		return nil, err
+	}
+
+	marketingInfo := &iberpctypes.Cw20MarketingInfo{
+		Project:     data.Project,
+		Description: data.Description,
+		Marketing:   data.Marketing,
+	}
+
+	if len(data.Logo) > 0 {
+		var logo iberpctypes.Cw20LogoUrl
+		if err := json.Unmarshal(data.Logo, &logo); err == nil && len(logo.Url) > 0 { // otherwise "embedded" or null
+			marketingInfo.Logo = &logo
+		}
+	}
+
+	return marketingInfo, nil
+}
+
+// GetCw20Balance returns the balances of the account on the CW-20 contracts.
+// If the optional block number is provided, the balances are queried at that height.
+//
+// The contracts are queried concurrently, failure on a contract is reported in the `error` field of that contract
+// instead of failing the whole response.
+func (m *WasmBackend) GetCw20Balance(accountAddress string, contractAddresses []string, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error) {
+	if len(contractAddresses) > m.wasmCfg.Cw20BalanceMaxContracts {
 		return nil, status.Error(codes.InvalidArgument, errors.New("too many contracts to query").Error())
 	}
 
@@ -50,96 +166,250 @@ func (m *WasmBackend) GetCw20Balance(accountAddress string, contractAddresses []
 		"account": accountAddress,
 	}
 
-	resForContracts := make([]berpctypes.GenericBackendResponse, 0)
+	if optionalBlockNumber != nil && *optionalBlockNumber > 0 {
+		res["height"] = *optionalBlockNumber
+	}
+
+	res["cw20Balances"] = m.getCw20BalancesMatrix([]string{accountAddress}, contractAddresses, optionalBlockNumber)[0]
+
+	return res, nil
+}
+
+// GetCw20Balances returns the balances of each account on each of the CW-20 contracts, as an account × contract matrix.
+// The rows of the matrix follow the order of the provided accounts, the columns follow the order of the provided contracts.
+func (m *WasmBackend) GetCw20Balances(accountAddresses []string, contractAddresses []string, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error) {
+	if len(accountAddresses) < 1 {
+		return nil, status.Error(codes.InvalidArgument, errors.New("no account provided").Error())
+	}
+	if len(accountAddresses) > m.wasmCfg.Cw20BalancesMaxAccounts {
+		return nil, status.Error(codes.InvalidArgument, errors.New("too many accounts to query").Error())
+	}
+	if len(contractAddresses) > m.wasmCfg.Cw20BalanceMaxContracts {
+		return nil, status.Error(codes.InvalidArgument, errors.New("too many contracts to query").Error())
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"accounts":  accountAddresses,
+		"contracts": contractAddresses,
+	}
+
+	if optionalBlockNumber != nil && *optionalBlockNumber > 0 {
+		res["height"] = *optionalBlockNumber
+	}
+
+	res["cw20Balances"] = m.getCw20BalancesMatrix(accountAddresses, contractAddresses, optionalBlockNumber)
+
+	return res, nil
+}
 
-	for _, contractAddress := range contractAddresses {
-		var display string
-		var decimals uint8
-		var balance *big.Int
+// getCw20BalancesMatrix queries the balances of each account on each contract.
+// The token info is resolved once per contract, then only the balance queries are fanned out,
+// bounded by the configured concurrency. A failure on a pair is reported in its cell instead of failing the whole.
+func (m *WasmBackend) getCw20BalancesMatrix(accountAddresses []string, contractAddresses []string, optionalBlockNumber *int64) [][]berpctypes.GenericBackendResponse {
+	tokenInfos := make([]*iberpctypes.Cw20TokenInfo, len(contractAddresses))
+	tokenInfoErrs := make([]error, len(contractAddresses))
+	runConcurrently(len(contractAddresses), m.wasmCfg.Cw20BalanceConcurrency, func(j int) {
+		tokenInfos[j], tokenInfoErrs[j] = m.getCw20TokenInfoForBalance(contractAddresses[j], optionalBlockNumber)
+	})
+
+	matrix := make([][]berpctypes.GenericBackendResponse, len(accountAddresses))
+	for i := range matrix {
+		matrix[i] = make([]berpctypes.GenericBackendResponse, len(contractAddresses))
+	}
+
+	runConcurrently(len(accountAddresses)*len(contractAddresses), m.wasmCfg.Cw20BalanceConcurrency, func(k int) {
+		i, j := k/len(contractAddresses), k%len(contractAddresses)
+		contractAddress := contractAddresses[j]
 
-		codeId, err := m.GetContractCodeId(contractAddress)
+		err := tokenInfoErrs[j]
+		var resForContract berpctypes.GenericBackendResponse
+		if err == nil {
+			resForContract, err = m.getCw20BalanceOfContract(accountAddresses[i], contractAddress, tokenInfos[j], optionalBlockNumber)
+		}
 		if err != nil {
-			return nil, err
+			resForContract = berpctypes.GenericBackendResponse{
+				"contract": contractAddress,
+				"error":    err.Error(),
+			}
+		}
+
+		matrix[i][j] = resForContract
+	})
+
+	return matrix
+}
+
+// getCw20TokenInfoForBalance resolves the token info to be used for the balance queries on the contract.
+// At the latest height, the metadata is resolved via GetCw20TokenMetadata so that it is served from/filled into the cache.
+// Returns nil without error if the address is not a contract.
+func (m *WasmBackend) getCw20TokenInfoForBalance(contractAddress string, optionalBlockNumber *int64) (*iberpctypes.Cw20TokenInfo, error) {
+	latest := optionalBlockNumber == nil || *optionalBlockNumber < 1
+
+	var errMetadata error
+	if latest {
+		var metadata *iberpctypes.Cw20TokenInfo
+		metadata, errMetadata = m.GetCw20TokenMetadata(contractAddress)
+		if errMetadata == nil {
+			return metadata, nil
 		}
+	}
+
+	codeId, err := m.GetContractCodeId(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if codeId == 0 {
+		return nil, nil
+	}
+
+	if latest {
+		return nil, errMetadata
+	}
 
-		if codeId == 0 {
-			display = ""
-			decimals = 0
-			balance = big.NewInt(0)
+	return m.queryCw20TokenInfoState(contractAddress, optionalBlockNumber)
+}
+
+// getCw20BalanceOfContract queries the balance of the account on the contract, using the pre-resolved token info.
+// A nil token info means the address is not a contract, the balance is zero.
+func (m *WasmBackend) getCw20BalanceOfContract(accountAddress, contractAddress string, tokenInfo *iberpctypes.Cw20TokenInfo, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error) {
+	var display, symbol string
+	var decimals uint8
+	var balance *big.Int
+
+	if tokenInfo == nil {
+		display = ""
+		decimals = 0
+		balance = big.NewInt(0)
+	} else {
+		if len(tokenInfo.Symbol) > 0 {
+			display = tokenInfo.Symbol
+		} else if len(tokenInfo.Name) > 0 {
+			display = tokenInfo.Name
 		} else {
-			tokenInfo, err := m.GetCw20TokenInfo(contractAddress)
-			if err != nil {
-				return nil, err
-			}
+			display = fmt.Sprintf("(%s)", contractAddress) // force value
+		}
 
-			if len(tokenInfo.Symbol) > 0 {
-				display = tokenInfo.Symbol
-			} else if len(tokenInfo.Name) > 0 {
-				display = tokenInfo.Name
-			} else {
-				display = fmt.Sprintf("(%s)", contractAddress) // force value
-			}
+		symbol = tokenInfo.Symbol
+		decimals = tokenInfo.Decimals
 
-			decimals = tokenInfo.Decimals
+		var err error
+		balance, err = m.queryCw20Balance(accountAddress, contractAddress, optionalBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-			state, err := m.SmartContractState(map[string]any{
-				"balance": map[string]any{
-					"address": accountAddress,
-				},
-			}, contractAddress, nil)
-			if err != nil {
-				return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract state").Error())
-			}
-			if len(state) < 1 {
-				return nil, status.Error(codes.NotFound, errors.New("no response contract state").Error())
-			}
+	return berpctypes.GenericBackendResponse{
+		"contract":         contractAddress,
+		"display":          display,
+		"symbol":           symbol,
+		"decimals":         decimals,
+		"balance":          balance.String(),
+		"formattedBalance": iberpctypes.Cw20TokenInfo{Decimals: decimals}.FormatAmount(balance),
+	}, nil
+}
 
-			var data struct {
-				Balance string `json:"balance"`
-			}
+// GetCw20BalanceHistory returns the balances of the account on the CW-20 contract, at each of the provided heights.
+// Failure at a height, e.g. the state was pruned, is reported in the `error` field of that height.
+func (m *WasmBackend) GetCw20BalanceHistory(accountAddress, contractAddress string, heights []int64) (berpctypes.GenericBackendResponse, error) {
+	const maxHeightsPerQuery = 50
+	if len(heights) < 1 {
+		return nil, status.Error(codes.InvalidArgument, errors.New("no height to query").Error())
+	}
+	if len(heights) > maxHeightsPerQuery {
+		return nil, status.Error(codes.InvalidArgument, errors.New("too many heights to query").Error())
+	}
+	for _, height := range heights {
+		if height < 1 {
+			return nil, status.Error(codes.InvalidArgument, errors.New("invalid height").Error())
+		}
+	}
 
-			err = json.Unmarshal(state, &data)
-			if err != nil {
-				return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unmarshal response").Error())
-			}
+	tokenInfo, err := m.GetCw20TokenInfo(contractAddress)
+	if err != nil {
+		return nil, err
+	}
 
-			var ok bool
-			balance, ok = new(big.Int).SetString(data.Balance, 10)
-			if !ok {
-				return nil, status.Error(codes.Internal, errors.New("failed to parse balance "+data.Balance).Error())
-			}
+	resForHeights := make([]berpctypes.GenericBackendResponse, 0, len(heights))
+	for _, height := range heights {
+		height := height
+
+		resForHeight := berpctypes.GenericBackendResponse{
+			"height": height,
 		}
 
-		resForContracts = append(resForContracts, berpctypes.GenericBackendResponse{
-			"contract": contractAddress,
-			"display":  display,
-			"decimals": decimals,
-			"balance":  balance.String(),
-		})
+		balance, err := m.queryCw20Balance(accountAddress, contractAddress, &height)
+		if err != nil {
+			resForHeight["error"] = err.Error()
+		} else {
+			resForHeight["balance"] = balance.String()
+		}
+
+		resForHeights = append(resForHeights, resForHeight)
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"account":  accountAddress,
+		"contract": contractAddress,
+		"symbol":   tokenInfo.Symbol,
+		"decimals": tokenInfo.Decimals,
+		"balances": resForHeights,
+	}, nil
+}
+
+// queryCw20Balance returns the balance of the account on the CW-20 contract, at the optional height.
+func (m *WasmBackend) queryCw20Balance(accountAddress, contractAddress string, optionalBlockNumber *int64) (*big.Int, error) {
+	state, err := m.SmartContractState(map[string]any{
+		"balance": map[string]any{
+			"address": accountAddress,
+		},
+	}, contractAddress, optionalBlockNumber)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract state").Error())
+	}
+	if len(state) < 1 {
+		return nil, status.Error(codes.NotFound, errors.New("no response contract state").Error())
 	}
 
-	res["cw20Balances"] = resForContracts
+	var data struct {
+		Balance string `json:"balance"`
+	}
 
-	return res, nil
+	err = json.Unmarshal(state, &data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unmarshal response").Error())
+	}
+
+	balance, ok := new(big.Int).SetString(data.Balance, 10)
+	if !ok {
+		return nil, status.Error(codes.Internal, errors.New("failed to parse balance "+data.Balance).Error())
+	}
+
+	return balance, nil
 }
 
 func (m *WasmBackend) SmartContractState(input map[string]any, contract string, optionalBlockNumber *int64) ([]byte, error) {
-	ctx := m.ctx
+	bz, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.smartContractStateWithContext(m.ctx, bz, contract, optionalBlockNumber)
+}
+
+// smartContractStateWithContext performs the smart query with the JSON encoded query data, using the provided context.
+func (m *WasmBackend) smartContractStateWithContext(ctx context.Context, queryData []byte, contract string, optionalBlockNumber *int64) ([]byte, error) {
 	if optionalBlockNumber != nil {
 		height := *optionalBlockNumber
 		if height > 0 {
-			ctx = berpcutils.QueryContextWithHeight(*optionalBlockNumber)
+			ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, fmt.Sprintf("%d", height))
 		}
 	}
 
-	bz, err := json.Marshal(input)
-	if err != nil {
-		return nil, err
-	}
-
 	resState, err := m.queryClient.WasmQueryClient.SmartContractState(ctx, &wasmtypes.QuerySmartContractStateRequest{
 		Address:   contract,
-		QueryData: bz,
+		QueryData: queryData,
 	})
 
 	if err != nil {
@@ -150,6 +420,50 @@ func (m *WasmBackend) SmartContractState(input map[string]any, contract string,
 }
 
 func (m *WasmBackend) GetCw20TokenInfo(contractAddress string) (*iberpctypes.Cw20TokenInfo, error) {
+	return m.queryCw20TokenInfo(contractAddress, nil)
+}
+
+// GetCw20TokenMetadata returns the name, symbol and decimals of the CW-20 token, the total supply is not provided.
+// The metadata is served from the cache if available, otherwise queried and cached.
+// Failed lookups, e.g. non-CW-20 contracts, are cached for a short time.
+func (m *WasmBackend) GetCw20TokenMetadata(contractAddress string) (*iberpctypes.Cw20TokenInfo, error) {
+	if metadata, found, errLookup := m.cw20TokenMetadataCache.Get(contractAddress); found {
+		if errLookup != nil {
+			return nil, errLookup
+		}
+		return &metadata, nil
+	}
+
+	tokenInfo, err := m.GetCw20TokenInfo(contractAddress)
+	if err != nil {
+		m.cw20TokenMetadataCache.SetFailure(contractAddress, err)
+		return nil, err
+	}
+
+	metadata := iberpctypes.Cw20TokenInfo{
+		Name:     tokenInfo.Name,
+		Symbol:   tokenInfo.Symbol,
+		Decimals: tokenInfo.Decimals,
+	}
+	m.cw20TokenMetadataCache.Set(contractAddress, metadata)
+
+	return &metadata, nil
+}
+
+func (m *WasmBackend) InvalidateCw20TokenMetadata(contractAddress string) {
+	m.cw20TokenMetadataCache.Invalidate(contractAddress)
+}
+
+func (m *WasmBackend) GetCw20TokenMetadataCacheStats() berpctypes.GenericBackendResponse {
+	hits, misses := m.cw20TokenMetadataCache.Stats()
+	return berpctypes.GenericBackendResponse{
+		"hits":   hits,
+		"misses": misses,
+	}
+}
+
+// queryCw20TokenInfo returns the token info of the CW-20 contract, at the optional height.
+func (m *WasmBackend) queryCw20TokenInfo(contractAddress string, optionalBlockNumber *int64) (*iberpctypes.Cw20TokenInfo, error) {
 	codeId, err := m.GetContractCodeId(contractAddress)
 	if err != nil {
 		return nil, err
@@ -159,9 +473,15 @@ func (m *WasmBackend) GetCw20TokenInfo(contractAddress string) (*iberpctypes.Cw2
 		return nil, status.Error(codes.NotFound, errors.New(contractAddress+" is not a contract").Error())
 	}
 
+	return m.queryCw20TokenInfoState(contractAddress, optionalBlockNumber)
+}
+
+// queryCw20TokenInfoState performs the `token_info` query, at the optional height.
+// Contract: the address must be a contract.
+func (m *WasmBackend) queryCw20TokenInfoState(contractAddress string, optionalBlockNumber *int64) (*iberpctypes.Cw20TokenInfo, error) {
 	state, err := m.SmartContractState(map[string]any{
 		"token_info": map[string]any{},
-	}, contractAddress, nil)
+	}, contractAddress, optionalBlockNumber)
 	if err != nil {
 		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract state").Error())
 	}
@@ -190,3 +510,162 @@ func (m *WasmBackend) GetCw20TokenInfo(contractAddress string) (*iberpctypes.Cw2
 
 	return &data, nil
 }
+
+const (
+	defaultCw20AllowancesPageSize = 10
+	maxCw20AllowancesPageSize     = 30 // the limit enforced by cw20-base
+)
+
+// GetCw20Allowance returns the allowance granted by the owner to the spender, on the CW-20 contract.
+//   - allowance: the remaining amount the spender can spend.
+//   - expires: the decoded expiration, one of `never`, `at_height` or `at_time`.
+func (m *WasmBackend) GetCw20Allowance(ownerAddress, spenderAddress, contractAddress string) (berpctypes.GenericBackendResponse, error) {
+	state, err := m.SmartContractState(map[string]any{
+		"allowance": map[string]any{
+			"owner":   ownerAddress,
+			"spender": spenderAddress,
+		},
+	}, contractAddress, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract state").Error())
+	}
+	if len(state) < 1 {
+		return nil, status.Error(codes.NotFound, errors.New("no response contract state").Error())
+	}
+
+	var data iberpctypes.Cw20AllowanceInfo
+
+	err = json.Unmarshal(state, &data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unmarshal response").Error())
+	}
+
+	if data.AllowanceAmount() == nil {
+		return nil, status.Error(codes.Internal, errors.New("failed to parse allowance "+data.Allowance).Error())
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"contract":  contractAddress,
+		"owner":     ownerAddress,
+		"spender":   spenderAddress,
+		"allowance": data.Allowance,
+		"expires":   data.Expires.ToResponse(),
+	}, nil
+}
+
+// GetCw20AllAllowances returns the allowances granted by the owner, on the CW-20 contract, paginated by spender.
+func (m *WasmBackend) GetCw20AllAllowances(ownerAddress, contractAddress string, startAfter string, limit int) (berpctypes.GenericBackendResponse, error) {
+	allowances, nextStartAfter, err := m.queryCw20Allowances("all_allowances", "owner", ownerAddress, contractAddress, startAfter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resForAllowances := make([]berpctypes.GenericBackendResponse, 0)
+	for _, allowance := range allowances {
+		resForAllowances = append(resForAllowances, berpctypes.GenericBackendResponse{
+			"spender":   allowance.Spender,
+			"allowance": allowance.Allowance,
+			"expires":   allowance.Expires.ToResponse(),
+		})
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract":   contractAddress,
+		"owner":      ownerAddress,
+		"allowances": resForAllowances,
+	}
+	if len(nextStartAfter) > 0 {
+		res["nextStartAfter"] = nextStartAfter
+	}
+
+	return res, nil
+}
+
+// GetCw20AllSpenderAllowances returns the allowances granted to the spender, on the CW-20 contract, paginated by owner.
+func (m *WasmBackend) GetCw20AllSpenderAllowances(spenderAddress, contractAddress string, startAfter string, limit int) (berpctypes.GenericBackendResponse, error) {
+	allowances, nextStartAfter, err := m.queryCw20Allowances("all_spender_allowances", "spender", spenderAddress, contractAddress, startAfter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resForAllowances := make([]berpctypes.GenericBackendResponse, 0)
+	for _, allowance := range allowances {
+		resForAllowances = append(resForAllowances, berpctypes.GenericBackendResponse{
+			"owner":     allowance.Owner,
+			"allowance": allowance.Allowance,
+			"expires":   allowance.Expires.ToResponse(),
+		})
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract":   contractAddress,
+		"spender":    spenderAddress,
+		"allowances": resForAllowances,
+	}
+	if len(nextStartAfter) > 0 {
+		res["nextStartAfter"] = nextStartAfter
+	}
+
+	return res, nil
+}
+
+// queryCw20Allowances performs the paginated allowances query, returns the allowances
+// and the cursor for the next page, empty if there is no more page.
+func (m *WasmBackend) queryCw20Allowances(queryName, addressField, address, contractAddress string, startAfter string, limit int) (allowances []iberpctypes.Cw20AllowanceInfo, nextStartAfter string, err error) {
+	if limit < 0 || limit > maxCw20AllowancesPageSize {
+		err = berpctypes.ErrBadPageSize
+		return
+	}
+	if limit == 0 {
+		limit = defaultCw20AllowancesPageSize
+	}
+
+	queryContent := map[string]any{
+		addressField: address,
+		"limit":      limit,
+	}
+	if len(startAfter) > 0 {
+		queryContent["start_after"] = startAfter
+	}
+
+	state, errQuery := m.SmartContractState(map[string]any{
+		queryName: queryContent,
+	}, contractAddress, nil)
+	if errQuery != nil {
+		err = status.Error(codes.Internal, errors.Wrap(errQuery, "failed to get contract state").Error())
+		return
+	}
+	if len(state) < 1 {
+		err = status.Error(codes.NotFound, errors.New("no response contract state").Error())
+		return
+	}
+
+	var data struct {
+		Allowances []iberpctypes.Cw20AllowanceInfo `json:"allowances"`
+	}
+
+	errUnmarshal := json.Unmarshal(state, &data)
+	if errUnmarshal != nil {
+		err = status.Error(codes.Internal, errors.Wrap(errUnmarshal, "failed to unmarshal response").Error())
+		return
+	}
+
+	for _, allowance := range data.Allowances {
+		if allowance.AllowanceAmount() == nil {
+			err = status.Error(codes.Internal, errors.New("failed to parse allowance "+allowance.Allowance).Error())
+			return
+		}
+	}
+
+	allowances = data.Allowances
+	if len(allowances) == limit {
+		last := allowances[len(allowances)-1]
+		if addressField == "owner" {
+			nextStartAfter = last.Spender
+		} else {
+			nextStartAfter = last.Owner
+		}
+	}
+
+	return
+}
@@ -0,0 +1,177 @@
+package wasm
+
+import (
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"time"
+)
+
+// Cw20DenomPrefix is the prefix of the denom those represent CW-20 tokens, format: `cw20:<contract>`.
+const Cw20DenomPrefix = "cw20:"
+
+const cw20DenomsCacheDuration = 10 * time.Minute
+
+type cw20DenomsCache struct {
+	mutex      *sync.Mutex
+	denoms     map[string]string // never modified once cached
+	expiredAt  time.Time
+	refreshing bool
+}
+
+func newCw20DenomsCache() *cw20DenomsCache {
+	return &cw20DenomsCache{
+		mutex: &sync.Mutex{},
+	}
+}
+
+// GetCw20Denoms returns the CW-20 tokens as `cw20:<contract>` denom to symbol.
+// The tokens are the configured known CW-20 contracts, plus the contracts discovered by scanning the stored codes
+// if enabled. A code is considered CW-20 if the `token_info` query succeeds on its first contract.
+//
+// Since scanning is expensive, the result is cached and refreshed in background when expired,
+// the stale result is served meanwhile. Before the first refresh completes, only the known contracts are provided.
+func (m *WasmBackend) GetCw20Denoms() (map[string]string, error) {
+	m.cw20DenomsCache.mutex.Lock()
+	denoms := m.cw20DenomsCache.denoms
+	if (denoms == nil || time.Now().After(m.cw20DenomsCache.expiredAt)) && !m.cw20DenomsCache.refreshing {
+		m.cw20DenomsCache.refreshing = true
+		go m.refreshCw20Denoms()
+	}
+	m.cw20DenomsCache.mutex.Unlock()
+
+	if denoms == nil {
+		denoms = make(map[string]string)
+		m.putKnownCw20Denoms(denoms)
+		return denoms, nil
+	}
+
+	return copyDenoms(denoms), nil
+}
+
+// refreshCw20Denoms rebuilds the CW-20 denoms and puts them into the cache.
+func (m *WasmBackend) refreshCw20Denoms() {
+	denoms := make(map[string]string)
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("panic while refreshing CW-20 denoms", "error", r)
+			denoms = nil
+		}
+
+		m.cw20DenomsCache.mutex.Lock()
+		defer m.cw20DenomsCache.mutex.Unlock()
+
+		if denoms != nil {
+			m.cw20DenomsCache.denoms = denoms
+			m.cw20DenomsCache.expiredAt = time.Now().Add(cw20DenomsCacheDuration)
+		}
+		m.cw20DenomsCache.refreshing = false
+	}()
+
+	m.putKnownCw20Denoms(denoms)
+
+	if m.wasmCfg.ScanCw20Contracts {
+		if err := m.scanCw20Denoms(denoms); err != nil {
+			// keep the discovered, the rest will be discovered in the next scan
+			m.logger.Error("failed to scan CW-20 contracts", "error", err)
+		}
+	}
+}
+
+// putKnownCw20Denoms puts the configured known CW-20 contracts into the provided map.
+func (m *WasmBackend) putKnownCw20Denoms(denoms map[string]string) {
+	for _, contractAddress := range m.wasmCfg.Cw20Contracts {
+		tokenInfo, err := m.GetCw20TokenMetadata(contractAddress)
+		if err != nil {
+			m.logger.Error("failed to get token info of known CW-20 contract", "contract", contractAddress, "error", err)
+			continue
+		}
+
+		denoms[Cw20DenomPrefix+contractAddress] = tokenInfo.Symbol
+	}
+}
+
+// scanCw20Denoms discovers the CW-20 contracts by scanning the stored codes, put the discovered into the provided map.
+// The scan stops when either the maximum number of discovered contracts or checked codes is reached.
+func (m *WasmBackend) scanCw20Denoms(denoms map[string]string) error {
+	var scanned, scannedCodes int
+
+	var nextKeyCodes []byte
+	for {
+		resCodes, err := m.queryClient.WasmQueryClient.Codes(m.ctx, &wasmtypes.QueryCodesRequest{
+			Pagination: &query.PageRequest{
+				Key: nextKeyCodes,
+			},
+		})
+		if err != nil {
+			return status.Error(codes.Internal, errors.Wrap(err, "failed to query codes").Error())
+		}
+
+		for _, codeInfo := range resCodes.CodeInfos {
+			if scannedCodes >= m.wasmCfg.MaxScanCw20Codes {
+				return nil
+			}
+			scannedCodes++
+
+			isCw20Code := false
+			checked := false
+
+			var nextKeyContracts []byte
+			for {
+				resContracts, err := m.queryClient.WasmQueryClient.ContractsByCode(m.ctx, &wasmtypes.QueryContractsByCodeRequest{
+					CodeId: codeInfo.CodeID,
+					Pagination: &query.PageRequest{
+						Key: nextKeyContracts,
+					},
+				})
+				if err != nil {
+					return status.Error(codes.Internal, errors.Wrap(err, "failed to query contracts by code").Error())
+				}
+
+				for _, contractAddress := range resContracts.Contracts {
+					if scanned >= m.wasmCfg.MaxScanCw20Contracts {
+						return nil
+					}
+
+					tokenInfo, err := m.GetCw20TokenMetadata(contractAddress)
+					if !checked {
+						checked = true
+						isCw20Code = err == nil
+					}
+					if !isCw20Code {
+						break
+					}
+
+					scanned++
+					if err == nil {
+						denoms[Cw20DenomPrefix+contractAddress] = tokenInfo.Symbol
+					}
+				}
+
+				if !isCw20Code || resContracts.Pagination == nil || len(resContracts.Pagination.NextKey) == 0 {
+					break
+				}
+				nextKeyContracts = resContracts.Pagination.NextKey
+			}
+		}
+
+		if resCodes.Pagination == nil || len(resCodes.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKeyCodes = resCodes.Pagination.NextKey
+	}
+
+	return nil
+}
+
+func copyDenoms(denoms map[string]string) map[string]string {
+	res := make(map[string]string, len(denoms))
+	for denom, symbol := range denoms {
+		res[denom] = symbol
+	}
+	return res
+}
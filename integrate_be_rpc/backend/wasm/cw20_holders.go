@@ -0,0 +1,232 @@
+package wasm
+
+import (
+	"encoding/json"
+	"fmt"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math/big"
+	"sort"
+)
+
+const (
+	defaultCw20HoldersPageSize = 10
+	maxCw20HoldersPageSize     = 30 // the limit enforced by cw20-base
+)
+
+type cw20Holder struct {
+	account string
+	balance *big.Int
+	err     error
+}
+
+// GetCw20Holders returns the holders of the CW-20 token, paginated via the `all_accounts` query.
+// Each holder comes with its balance and its share of the total supply, in percent.
+// A failure on querying balance of a holder is reported in its `error` field instead of failing the whole.
+//
+// If sortByBalance is true, all the holders are enumerated and ranked by balance, descending,
+// and the pages follow the ranking. The ranking is cached for a short time, shared by the pages and the callers.
+// The token must not have more holders than the configured maximum to rank, 0 is ranking disabled.
+// Otherwise, the pages follow the account order of the contract.
+func (m *WasmBackend) GetCw20Holders(contractAddress string, startAfter string, limit int, sortByBalance bool) (berpctypes.GenericBackendResponse, error) {
+	if limit < 0 || limit > maxCw20HoldersPageSize {
+		return nil, berpctypes.ErrBadPageSize
+	}
+	if limit == 0 {
+		limit = defaultCw20HoldersPageSize
+	}
+
+	if sortByBalance && m.wasmCfg.Cw20HoldersMaxRank < 1 {
+		return nil, status.Error(codes.Unimplemented, "ranking holders by balance is disabled")
+	}
+
+	tokenInfo, err := m.GetCw20TokenInfo(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var holders []cw20Holder
+	var nextStartAfter string
+
+	if sortByBalance {
+		holders, nextStartAfter, err = m.getCw20HoldersRankedByBalance(contractAddress, startAfter, limit)
+	} else {
+		var accounts []string
+		accounts, err = m.queryCw20AllAccounts(contractAddress, startAfter, limit)
+		if err == nil {
+			holders = m.queryCw20HoldersBalance(contractAddress, accounts)
+			if len(accounts) == limit {
+				nextStartAfter = accounts[len(accounts)-1]
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resForHolders := make([]berpctypes.GenericBackendResponse, 0, len(holders))
+	for _, h := range holders {
+		resHolder := berpctypes.GenericBackendResponse{
+			"account": h.account,
+		}
+
+		if h.err != nil {
+			resHolder["error"] = h.err.Error()
+		} else {
+			resHolder["balance"] = h.balance.String()
+
+			if tokenInfo.TotalSupply != nil && tokenInfo.TotalSupply.Sign() > 0 {
+				share := new(big.Rat).SetFrac(new(big.Int).Mul(h.balance, big.NewInt(100)), tokenInfo.TotalSupply)
+				resHolder["sharePercent"] = share.FloatString(4)
+			}
+		}
+
+		resForHolders = append(resForHolders, resHolder)
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"symbol":   tokenInfo.Symbol,
+		"decimals": tokenInfo.Decimals,
+		"holders":  resForHolders,
+	}
+
+	if tokenInfo.TotalSupply != nil {
+		res["totalSupply"] = tokenInfo.TotalSupply.String()
+	}
+
+	if len(nextStartAfter) > 0 {
+		res["nextStartAfter"] = nextStartAfter
+	}
+
+	return res, nil
+}
+
+// getCw20HoldersRankedByBalance returns the page of the holders ranking right after the `startAfter` account.
+// The ranking is served from the cache if available, otherwise computed and cached.
+func (m *WasmBackend) getCw20HoldersRankedByBalance(contractAddress string, startAfter string, limit int) (holders []cw20Holder, nextStartAfter string, err error) {
+	ranked, err := m.cw20HoldersRankingCache.GetOrCompute(contractAddress, func() ([]cw20Holder, error) {
+		return m.rankCw20HoldersByBalance(contractAddress)
+	})
+	if err != nil {
+		return
+	}
+
+	start := 0
+	if len(startAfter) > 0 {
+		start = -1
+		for i, h := range ranked {
+			if h.account == startAfter {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			err = status.Error(codes.InvalidArgument, errors.New("start after account is not a holder").Error())
+			return
+		}
+	}
+
+	end := start + limit
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+
+	holders = ranked[start:end]
+	if end < len(ranked) {
+		nextStartAfter = holders[len(holders)-1].account
+	}
+
+	return
+}
+
+// rankCw20HoldersByBalance enumerates all the holders and ranks them by balance, descending.
+// Holders those failed to query balance are placed at the end.
+// The enumeration is aborted as soon as the holders exceed the configured maximum, before querying any balance.
+func (m *WasmBackend) rankCw20HoldersByBalance(contractAddress string) ([]cw20Holder, error) {
+	maxHoldersToRank := m.wasmCfg.Cw20HoldersMaxRank
+
+	var accounts []string
+	var lastAccount string
+	for {
+		pageAccounts, err := m.queryCw20AllAccounts(contractAddress, lastAccount, maxCw20HoldersPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, pageAccounts...)
+		if len(accounts) > maxHoldersToRank {
+			return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("too many holders to rank by balance, maximum %d", maxHoldersToRank))
+		}
+
+		if len(pageAccounts) < maxCw20HoldersPageSize {
+			break
+		}
+		lastAccount = pageAccounts[len(pageAccounts)-1]
+	}
+
+	ranked := m.queryCw20HoldersBalance(contractAddress, accounts)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if (ranked[i].err == nil) != (ranked[j].err == nil) {
+			return ranked[i].err == nil
+		}
+		if ranked[i].err == nil {
+			if cmp := ranked[i].balance.Cmp(ranked[j].balance); cmp != 0 {
+				return cmp > 0
+			}
+		}
+		return ranked[i].account < ranked[j].account
+	})
+
+	return ranked, nil
+}
+
+// queryCw20HoldersBalance queries the balances of the accounts concurrently,
+// a failure on an account is kept in its `err` instead of failing the whole.
+func (m *WasmBackend) queryCw20HoldersBalance(contractAddress string, accounts []string) []cw20Holder {
+	holders := make([]cw20Holder, len(accounts))
+
+	runConcurrently(len(accounts), m.wasmCfg.Cw20BalanceConcurrency, func(i int) {
+		balance, err := m.queryCw20Balance(accounts[i], contractAddress, nil)
+		holders[i] = cw20Holder{
+			account: accounts[i],
+			balance: balance,
+			err:     err,
+		}
+	})
+
+	return holders
+}
+
+// queryCw20AllAccounts returns the accounts those holding the token, via the `all_accounts` query.
+func (m *WasmBackend) queryCw20AllAccounts(contractAddress string, startAfter string, limit int) ([]string, error) {
+	queryContent := map[string]any{
+		"limit": limit,
+	}
+	if len(startAfter) > 0 {
+		queryContent["start_after"] = startAfter
+	}
+
+	state, err := m.SmartContractState(map[string]any{
+		"all_accounts": queryContent,
+	}, contractAddress, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract state").Error())
+	}
+	if len(state) < 1 {
+		return nil, status.Error(codes.NotFound, errors.New("no response contract state").Error())
+	}
+
+	var data struct {
+		Accounts []string `json:"accounts"`
+	}
+
+	err = json.Unmarshal(state, &data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unmarshal response").Error())
+	}
+
+	return data.Accounts, nil
+}
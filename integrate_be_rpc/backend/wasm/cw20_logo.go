@@ -0,0 +1,85 @@
+package wasm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	cw20LogoSizeCap = 5 * 1024 // the limit enforced by cw20-base
+
+	cw20LogoMimeTypeSvg = "image/svg+xml"
+	cw20LogoMimeTypePng = "image/png"
+)
+
+var pngHeader = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+// GetCw20Logo returns the embedded logo of the CW-20 token, via the `download_logo` query.
+//   - mimeType: either `image/svg+xml` or `image/png`.
+//   - data: the base64 encoded logo.
+//
+// The logo is refused if it is not a valid SVG/PNG or it exceeds the cw20-base size cap.
+func (m *WasmBackend) GetCw20Logo(contractAddress string) (berpctypes.GenericBackendResponse, error) {
+	state, err := m.SmartContractState(map[string]any{
+		"download_logo": map[string]any{},
+	}, contractAddress, nil)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, errors.Wrap(err, "failed to download logo").Error())
+	}
+	if len(state) < 1 {
+		return nil, status.Error(codes.NotFound, errors.New("no response contract state").Error())
+	}
+
+	var data struct {
+		MimeType string `json:"mime_type"`
+		Data     string `json:"data"`
+	}
+
+	err = json.Unmarshal(state, &data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unmarshal response").Error())
+	}
+
+	logo, err := base64.StdEncoding.DecodeString(data.Data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to decode logo").Error())
+	}
+
+	if len(logo) < 1 {
+		return nil, status.Error(codes.NotFound, "empty logo")
+	}
+
+	if len(logo) > cw20LogoSizeCap {
+		return nil, status.Error(codes.FailedPrecondition, "logo exceeds size limit")
+	}
+
+	switch data.MimeType {
+	case cw20LogoMimeTypeSvg:
+		if !isSvgLogo(logo) {
+			return nil, status.Error(codes.FailedPrecondition, "invalid SVG logo")
+		}
+	case cw20LogoMimeTypePng:
+		if !bytes.HasPrefix(logo, pngHeader) {
+			return nil, status.Error(codes.FailedPrecondition, "invalid PNG logo")
+		}
+	default:
+		return nil, status.Error(codes.FailedPrecondition, "not supported logo type "+data.MimeType)
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"mimeType": data.MimeType,
+		"data":     base64.StdEncoding.EncodeToString(logo),
+	}, nil
+}
+
+// isSvgLogo performs the same preamble check as cw20-base.
+func isSvgLogo(logo []byte) bool {
+	logo = bytes.TrimLeft(logo, " \t\r\n")
+	return bytes.HasPrefix(logo, []byte("<?xml")) || bytes.HasPrefix(logo, []byte("<svg"))
+}
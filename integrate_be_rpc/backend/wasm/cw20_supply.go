@@ -0,0 +1,109 @@
+package wasm
+
+import (
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxCw20SupplyHistorySamples is the maximum number of heights can be sampled at once by GetCw20SupplyHistory.
+const maxCw20SupplyHistorySamples = 100
+
+// GetCw20SupplyHistory returns the total supply of the CW-20 token sampled at heights
+// from `fromHeight` to `toHeight`, every `step` blocks. The `toHeight` is always sampled
+// and must not be greater than the latest block height.
+// Each sample contains the block time, failure on a sample is reported in its `error` field.
+func (m *WasmBackend) GetCw20SupplyHistory(contractAddress string, fromHeight, toHeight, step int64) (berpctypes.GenericBackendResponse, error) {
+	if fromHeight < 1 || toHeight < 1 {
+		return nil, status.Error(codes.InvalidArgument, errors.New("invalid height").Error())
+	}
+	if fromHeight > toHeight {
+		return nil, status.Error(codes.InvalidArgument, errors.New("from height must not be greater than to height").Error())
+	}
+	if step < 1 {
+		return nil, status.Error(codes.InvalidArgument, errors.New("step must be positive").Error())
+	}
+	if countCw20SupplyHistorySamples(fromHeight, toHeight, step) > maxCw20SupplyHistorySamples {
+		return nil, status.Error(codes.InvalidArgument, errors.New("too many heights to sample, increase the step or narrow the range").Error())
+	}
+
+	resStatus, err := m.clientCtx.Client.Status(m.ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get node status").Error())
+	}
+	if toHeight > resStatus.SyncInfo.LatestBlockHeight {
+		return nil, status.Error(codes.InvalidArgument, errors.New("to height must not be greater than the latest block height").Error())
+	}
+
+	tokenInfo, err := m.GetCw20TokenMetadata(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	heights := cw20SupplyHistoryHeights(fromHeight, toHeight, step)
+
+	samples := make([]berpctypes.GenericBackendResponse, 0, len(heights))
+	for _, height := range heights {
+		height := height
+
+		sample := berpctypes.GenericBackendResponse{
+			"height": height,
+		}
+		samples = append(samples, sample)
+
+		resBlock, err := m.clientCtx.Client.Block(m.ctx, &height)
+		if err != nil {
+			sample["error"] = errors.Wrap(err, "failed to get block").Error()
+			continue
+		}
+		sample["timeEpochUTC"] = resBlock.Block.Time.UTC().Unix()
+
+		tokenInfoAtHeight, err := m.queryCw20TokenInfoState(contractAddress, &height)
+		if err != nil {
+			sample["error"] = err.Error()
+			continue
+		}
+		if tokenInfoAtHeight.TotalSupply == nil {
+			sample["error"] = "no total supply"
+			continue
+		}
+
+		sample["totalSupply"] = tokenInfoAtHeight.TotalSupply.String()
+		sample["formattedTotalSupply"] = tokenInfo.FormatAmount(tokenInfoAtHeight.TotalSupply)
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"symbol":   tokenInfo.Symbol,
+		"decimals": tokenInfo.Decimals,
+		"supplies": samples,
+	}, nil
+}
+
+// countCw20SupplyHistorySamples returns the number of heights sampled by GetCw20SupplyHistory,
+// including the `toHeight` which is appended when it is not reached by stepping.
+func countCw20SupplyHistorySamples(fromHeight, toHeight, step int64) int64 {
+	samples := (toHeight-fromHeight)/step + 1
+	if (toHeight-fromHeight)%step != 0 {
+		samples++
+	}
+	return samples
+}
+
+// cw20SupplyHistoryHeights returns the heights sampled by GetCw20SupplyHistory, from `fromHeight` every `step` blocks,
+// plus the `toHeight` if it is not reached by stepping. The stepping never overflows.
+// Contract: 0 < fromHeight <= toHeight, step > 0.
+func cw20SupplyHistoryHeights(fromHeight, toHeight, step int64) []int64 {
+	heights := make([]int64, 0, countCw20SupplyHistorySamples(fromHeight, toHeight, step))
+	for height := fromHeight; ; height += step {
+		heights = append(heights, height)
+		if toHeight-height < step {
+			break
+		}
+	}
+	if heights[len(heights)-1] != toHeight {
+		heights = append(heights, toHeight)
+	}
+	return heights
+}
@@ -0,0 +1,146 @@
+package wasm
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func Test_countCw20SupplyHistorySamples(t *testing.T) {
+	testcases := []struct {
+		name       string
+		fromHeight int64
+		toHeight   int64
+		step       int64
+		want       int64
+	}{
+		{
+			name:       "single height",
+			fromHeight: 10,
+			toHeight:   10,
+			step:       1,
+			want:       1,
+		},
+		{
+			name:       "every block",
+			fromHeight: 1,
+			toHeight:   100,
+			step:       1,
+			want:       100,
+		},
+		{
+			name:       "to height reached by stepping",
+			fromHeight: 1,
+			toHeight:   21,
+			step:       10,
+			want:       3, // 1, 11, 21
+		},
+		{
+			name:       "to height appended",
+			fromHeight: 1,
+			toHeight:   25,
+			step:       10,
+			want:       4, // 1, 11, 21, 25
+		},
+		{
+			name:       "step larger than range",
+			fromHeight: 1,
+			toHeight:   5,
+			step:       10,
+			want:       2, // 1, 5
+		},
+		{
+			name:       "appended to height exceeds the cap",
+			fromHeight: 1,
+			toHeight:   1000,
+			step:       10,
+			want:       101, // 1, 11, ..., 991, 1000
+		},
+		{
+			name:       "near max int64",
+			fromHeight: 1,
+			toHeight:   math.MaxInt64,
+			step:       math.MaxInt64 / 50,
+			want:       52,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := countCw20SupplyHistorySamples(tt.fromHeight, tt.toHeight, tt.step)
+			require.Equal(t, tt.want, got)
+
+			// must match the heights actually sampled
+			require.Len(t, cw20SupplyHistoryHeights(tt.fromHeight, tt.toHeight, tt.step), int(got))
+		})
+	}
+}
+
+func Test_cw20SupplyHistoryHeights(t *testing.T) {
+	testcases := []struct {
+		name       string
+		fromHeight int64
+		toHeight   int64
+		step       int64
+		want       []int64
+	}{
+		{
+			name:       "single height",
+			fromHeight: 10,
+			toHeight:   10,
+			step:       1,
+			want:       []int64{10},
+		},
+		{
+			name:       "to height reached by stepping",
+			fromHeight: 1,
+			toHeight:   21,
+			step:       10,
+			want:       []int64{1, 11, 21},
+		},
+		{
+			name:       "to height appended",
+			fromHeight: 1,
+			toHeight:   25,
+			step:       10,
+			want:       []int64{1, 11, 21, 25},
+		},
+		{
+			name:       "step larger than range",
+			fromHeight: 1,
+			toHeight:   5,
+			step:       10,
+			want:       []int64{1, 5},
+		},
+		{
+			name:       "step does not overflow at max int64",
+			fromHeight: math.MaxInt64 - 10,
+			toHeight:   math.MaxInt64,
+			step:       4,
+			want:       []int64{math.MaxInt64 - 10, math.MaxInt64 - 6, math.MaxInt64 - 2, math.MaxInt64},
+		},
+		{
+			name:       "step max int64",
+			fromHeight: 1,
+			toHeight:   math.MaxInt64,
+			step:       math.MaxInt64,
+			want:       []int64{1, math.MaxInt64},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cw20SupplyHistoryHeights(tt.fromHeight, tt.toHeight, tt.step)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("near max int64", func(t *testing.T) {
+		step := int64(math.MaxInt64 / 50)
+		got := cw20SupplyHistoryHeights(1, math.MaxInt64, step)
+		require.Len(t, got, 52)
+		require.Equal(t, int64(1), got[0])
+		require.Equal(t, int64(math.MaxInt64), got[len(got)-1])
+		for i := 1; i < len(got); i++ {
+			require.Greater(t, got[i], got[i-1])
+		}
+	})
+}
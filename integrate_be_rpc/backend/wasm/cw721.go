@@ -0,0 +1,363 @@
+package wasm
+
+import (
+	"encoding/json"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	iberpctypes "github.com/bcdevtools/wasm-block-explorer-rpc-cosmos/integrate_be_rpc/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// GetCw721ContractInfo will return information of CW-721 NFT collection by address.
+//   - name: the name of the collection.
+//   - symbol: the symbol of the collection.
+//   - numTokens: the number of tokens issued, optional.
+//   - minter: the minter address, optional.
+//
+// If failed to query any of the mandatory fields, it will return an error.
+// If failed to query the optional field, it will continue.
+func (m *WasmBackend) GetCw721ContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error) {
+	collectionInfo, err := m.getCw721CollectionInfoWithOptionalFields(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"name":     collectionInfo.Name,
+		"symbol":   collectionInfo.Symbol,
+	}
+
+	if collectionInfo.NumTokens != nil {
+		res["numTokens"] = *collectionInfo.NumTokens
+	}
+
+	if len(collectionInfo.Minter) > 0 {
+		res["minter"] = collectionInfo.Minter
+	}
+
+	return res, nil
+}
+
+func (m *WasmBackend) getCw721CollectionInfoWithOptionalFields(contractAddress string) (*iberpctypes.Cw721CollectionInfo, error) {
+	collectionInfo, err := m.queryCw721CollectionInfo(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if numTokens, err := m.queryCw721NumTokens(contractAddress); err == nil {
+		collectionInfo.NumTokens = &numTokens
+	}
+
+	if minter, err := m.queryCw721Minter(contractAddress); err == nil {
+		collectionInfo.Minter = minter
+	}
+
+	return collectionInfo, nil
+}
+
+// queryCw721CollectionInfo returns the collection info provided by the `contract_info` query.
+func (m *WasmBackend) queryCw721CollectionInfo(contractAddress string) (*iberpctypes.Cw721CollectionInfo, error) {
+	codeId, err := m.GetContractCodeId(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if codeId == 0 {
+		return nil, status.Error(codes.NotFound, errors.New(contractAddress+" is not a contract").Error())
+	}
+
+	state, err := m.SmartContractState(map[string]any{
+		"contract_info": map[string]any{},
+	}, contractAddress, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract state").Error())
+	}
+	if len(state) < 1 {
+		return nil, status.Error(codes.NotFound, errors.New("no response contract state").Error())
+	}
+
+	var data iberpctypes.Cw721CollectionInfo
+
+	err = json.Unmarshal(state, &data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unmarshal response").Error())
+	}
+
+	if len(data.Name) == 0 && len(data.Symbol) == 0 {
+		return nil, status.Error(codes.NotFound, errors.New("no collection info found").Error())
+	}
+
+	return &data, nil
+}
+
+func (m *WasmBackend) queryCw721NumTokens(contractAddress string) (uint64, error) {
+	state, err := m.SmartContractState(map[string]any{
+		"num_tokens": map[string]any{},
+	}, contractAddress, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var data iberpctypes.Cw721NumTokens
+	if err := json.Unmarshal(state, &data); err != nil {
+		return 0, err
+	}
+
+	return data.Count, nil
+}
+
+func (m *WasmBackend) queryCw721Minter(contractAddress string) (string, error) {
+	state, err := m.SmartContractState(map[string]any{
+		"minter": map[string]any{},
+	}, contractAddress, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var data iberpctypes.Cw721Minter
+	if err := json.Unmarshal(state, &data); err != nil {
+		return "", err
+	}
+
+	if data.Minter == nil {
+		return "", nil
+	}
+
+	return *data.Minter, nil
+}
+
+// GetCw721Token returns the detail of the CW-721 NFT, queried by `all_nft_info`.
+//   - owner: the owner of the NFT.
+//   - approvals: the spenders approved to transfer the NFT, with expiration.
+//   - tokenUri: the token URI, optional.
+//   - extension: the on-chain metadata, follows the cw721-metadata-onchain schema if possible, optional.
+//
+// The `ipfs://` URIs are resolved to the configured IPFS gateway if any, the resolved URIs are not fetched.
+func (m *WasmBackend) GetCw721Token(contractAddress, tokenId string) (berpctypes.GenericBackendResponse, error) {
+	if len(tokenId) < 1 {
+		return nil, status.Error(codes.InvalidArgument, errors.New("token id is required").Error())
+	}
+
+	state, err := m.SmartContractState(map[string]any{
+		"all_nft_info": map[string]any{
+			"token_id": tokenId,
+		},
+	}, contractAddress, nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such contract") {
+			return nil, status.Error(codes.NotFound, errors.New(contractAddress+" is not a contract").Error())
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, errors.New("token not found").Error())
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract state").Error())
+	}
+	if len(state) < 1 {
+		return nil, status.Error(codes.NotFound, errors.New("no response contract state").Error())
+	}
+
+	var data iberpctypes.Cw721AllNftInfo
+
+	err = json.Unmarshal(state, &data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unmarshal response").Error())
+	}
+
+	approvals := make([]berpctypes.GenericBackendResponse, 0, len(data.Access.Approvals))
+	for _, approval := range data.Access.Approvals {
+		approvals = append(approvals, berpctypes.GenericBackendResponse{
+			"spender": approval.Spender,
+			"expires": approval.Expires.ToResponse(),
+		})
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract":  contractAddress,
+		"tokenId":   tokenId,
+		"owner":     data.Access.Owner,
+		"approvals": approvals,
+	}
+
+	if tokenUri := data.Info.TokenUri; tokenUri != nil && len(*tokenUri) > 0 {
+		res["tokenUri"] = *tokenUri
+		if resolved, ok := m.resolveIpfsUri(*tokenUri); ok {
+			res["resolvedTokenUri"] = resolved
+		}
+	}
+
+	if extension := m.decodeCw721Extension(data.Info.Extension); extension != nil {
+		res["extension"] = extension
+	}
+
+	return res, nil
+}
+
+// decodeCw721Extension decodes the extension following the cw721-metadata-onchain schema,
+// with `ipfs://` URIs resolved. Extension of other schemas is returned as decoded JSON.
+func (m *WasmBackend) decodeCw721Extension(extension json.RawMessage) any {
+	if len(extension) < 1 || string(extension) == "null" {
+		return nil
+	}
+
+	decodeGeneric := func() any {
+		var decoded any
+		if err := json.Unmarshal(extension, &decoded); err != nil {
+			return nil
+		}
+		return decoded
+	}
+
+	var metadata iberpctypes.Cw721Metadata
+	if err := json.Unmarshal(extension, &metadata); err != nil {
+		return decodeGeneric()
+	}
+
+	res := berpctypes.GenericBackendResponse{}
+
+	putString := func(key string, value *string, resolveIpfs bool) {
+		if value == nil || len(*value) < 1 {
+			return
+		}
+		res[key] = *value
+		if !resolveIpfs {
+			return
+		}
+		if resolved, ok := m.resolveIpfsUri(*value); ok {
+			res["resolved"+strings.ToUpper(key[:1])+key[1:]] = resolved
+		}
+	}
+
+	putString("name", metadata.Name, false)
+	putString("description", metadata.Description, false)
+	putString("image", metadata.Image, true)
+	putString("imageData", metadata.ImageData, false)
+	putString("externalUrl", metadata.ExternalUrl, true)
+	putString("backgroundColor", metadata.BackgroundColor, false)
+	putString("animationUrl", metadata.AnimationUrl, true)
+	putString("youtubeUrl", metadata.YoutubeUrl, false)
+
+	if len(metadata.Attributes) > 0 {
+		attributes := make([]berpctypes.GenericBackendResponse, 0, len(metadata.Attributes))
+		for _, attribute := range metadata.Attributes {
+			resAttribute := berpctypes.GenericBackendResponse{
+				"traitType": attribute.TraitType,
+				"value":     attribute.Value,
+			}
+			if attribute.DisplayType != nil {
+				resAttribute["displayType"] = *attribute.DisplayType
+			}
+			attributes = append(attributes, resAttribute)
+		}
+		res["attributes"] = attributes
+	}
+
+	if len(res) < 1 {
+		// not following the cw721-metadata-onchain schema
+		return decodeGeneric()
+	}
+
+	return res
+}
+
+// resolveIpfsUri resolves the `ipfs://` URI to the configured IPFS gateway.
+// Returns false if the URI is not an IPFS URI or no gateway configured.
+func (m *WasmBackend) resolveIpfsUri(uri string) (string, bool) {
+	const ipfsScheme = "ipfs://"
+
+	if len(m.wasmCfg.IpfsGateway) < 1 || !strings.HasPrefix(strings.ToLower(uri), ipfsScheme) {
+		return "", false
+	}
+
+	path := strings.TrimPrefix(uri[len(ipfsScheme):], "ipfs/")
+	return strings.TrimSuffix(m.wasmCfg.IpfsGateway, "/") + "/" + path, true
+}
+
+const (
+	defaultCw721TokensPageSize = 10
+	maxCw721TokensPageSize     = 100 // the limit enforced by cw721-base
+)
+
+// GetCw721Tokens returns the token IDs owned by the account, on each of the CW-721 contracts.
+// The `tokens` query is paged per contract by `limit` and the cursor of that contract in `startAfters`, keyed by contract.
+// The `nextStartAfter` of a contract is provided whenever its page is not empty, because the contract may cap the page
+// below the requested limit, so the listing of a contract is complete only when an empty page is returned.
+// The contracts are queried concurrently, a failure on a contract is reported in its `error` field instead of failing the whole.
+func (m *WasmBackend) GetCw721Tokens(ownerAddress string, contractAddresses []string, startAfters map[string]string, limit int) (berpctypes.GenericBackendResponse, error) {
+	if len(contractAddresses) > m.wasmCfg.Cw20BalanceMaxContracts {
+		return nil, status.Error(codes.InvalidArgument, errors.New("too many contracts to query").Error())
+	}
+	if limit < 0 || limit > maxCw721TokensPageSize {
+		return nil, berpctypes.ErrBadPageSize
+	}
+	if limit == 0 {
+		limit = defaultCw721TokensPageSize
+	}
+
+	resForContracts := make([]berpctypes.GenericBackendResponse, len(contractAddresses))
+
+	runConcurrently(len(contractAddresses), m.wasmCfg.Cw20BalanceConcurrency, func(i int) {
+		contractAddress := contractAddresses[i]
+
+		resForContract := berpctypes.GenericBackendResponse{
+			"contract": contractAddress,
+		}
+
+		tokenIds, err := m.queryCw721Tokens(ownerAddress, contractAddress, startAfters[contractAddress], limit)
+		if err != nil {
+			resForContract["error"] = err.Error()
+		} else {
+			resForContract["tokenIds"] = tokenIds
+			if len(tokenIds) > 0 {
+				resForContract["nextStartAfter"] = tokenIds[len(tokenIds)-1]
+			}
+		}
+
+		resForContracts[i] = resForContract
+	})
+
+	return berpctypes.GenericBackendResponse{
+		"owner":       ownerAddress,
+		"cw721Tokens": resForContracts,
+	}, nil
+}
+
+func (m *WasmBackend) queryCw721Tokens(ownerAddress, contractAddress string, startAfter string, limit int) ([]string, error) {
+	queryContent := map[string]any{
+		"owner": ownerAddress,
+		"limit": limit,
+	}
+	if len(startAfter) > 0 {
+		queryContent["start_after"] = startAfter
+	}
+
+	state, err := m.SmartContractState(map[string]any{
+		"tokens": queryContent,
+	}, contractAddress, nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such contract") {
+			return nil, status.Error(codes.NotFound, errors.New(contractAddress+" is not a contract").Error())
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get contract state").Error())
+	}
+	if len(state) < 1 {
+		return nil, status.Error(codes.NotFound, errors.New("no response contract state").Error())
+	}
+
+	var data struct {
+		Tokens []string `json:"tokens"`
+	}
+
+	err = json.Unmarshal(state, &data)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unmarshal response").Error())
+	}
+
+	if data.Tokens == nil {
+		data.Tokens = make([]string, 0)
+	}
+
+	return data.Tokens, nil
+}
@@ -0,0 +1,85 @@
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QuerySmartContract performs the smart query against the contract, at the optional height, and returns the JSON result.
+// Both the query and the result are passed through as-is, so big numbers are not corrupted by float64 decoding.
+// Since this is a passthrough for public usage, it enforces the configured safety limits:
+//   - maximum size of the query.
+//   - allowed top-level query keys, if configured.
+//   - timeout of the query.
+//   - maximum size of the response.
+func (m *WasmBackend) QuerySmartContract(contractAddress string, queryData json.RawMessage, optionalBlockNumber *int64) (berpctypes.GenericBackendResponse, error) {
+	if len(queryData) > m.wasmCfg.SmartQueryMaxQuerySize {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("query size exceeds limit %d bytes", m.wasmCfg.SmartQueryMaxQuerySize))
+	}
+
+	// only the top-level keys are decoded, the values are kept untouched
+	var query map[string]json.RawMessage
+	if err := json.Unmarshal(queryData, &query); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "query must be a JSON object")
+	}
+
+	if len(query) < 1 {
+		return nil, status.Error(codes.InvalidArgument, "empty query")
+	}
+
+	if len(m.wasmCfg.SmartQueryAllowedKeys) > 0 {
+		for key := range query {
+			if !isSmartQueryKeyAllowed(key, m.wasmCfg.SmartQueryAllowedKeys) {
+				return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("query key %s is not allowed", key))
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.wasmCfg.SmartQueryTimeout)
+	defer cancel()
+
+	state, err := m.smartContractStateWithContext(ctx, queryData, contractAddress, optionalBlockNumber)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, status.Error(codes.DeadlineExceeded, "query timed out")
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to query contract").Error())
+	}
+
+	if len(state) > m.wasmCfg.SmartQueryMaxResponseSize {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("response size exceeds limit %d bytes", m.wasmCfg.SmartQueryMaxResponseSize))
+	}
+
+	var data json.RawMessage
+	if len(state) > 0 {
+		if !json.Valid(state) {
+			return nil, status.Error(codes.Internal, "response is not a valid JSON")
+		}
+		data = state
+	}
+
+	res := berpctypes.GenericBackendResponse{
+		"contract": contractAddress,
+		"data":     data,
+	}
+
+	if optionalBlockNumber != nil && *optionalBlockNumber > 0 {
+		res["height"] = *optionalBlockNumber
+	}
+
+	return res, nil
+}
+
+func isSmartQueryKeyAllowed(key string, allowedKeys []string) bool {
+	for _, allowedKey := range allowedKeys {
+		if key == allowedKey {
+			return true
+		}
+	}
+	return false
+}
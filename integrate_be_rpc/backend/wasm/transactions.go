@@ -1,26 +1,301 @@
 package wasm
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/pkg/errors"
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"regexp"
+	"strings"
 )
 
-func (m *WasmBackend) GetWasmTransactionInvolversByHash(hash string) (berpctypes.MessageInvolversResult, error) {
-	// TODO BE: implement
-	return nil, nil
+var patternTxHash = regexp.MustCompile(`^(0[xX])?[\da-fA-F]{64}$`)
+
+// GetWasmTransactionInvolversByHash returns the involvers of the transaction, extracted from the Wasm messages
+// by the registered message involvers extractors. Non-Wasm messages are ignored.
+func (m *WasmBackend) GetWasmTransactionInvolversByHash(hashStr string) (berpctypes.MessageInvolversResult, error) {
+	hash, err := normalizeTxHash(hashStr)
+	if err != nil {
+		return nil, err
+	}
+
+	hashBytes, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	// the Tendermint tx provides both the raw tx, required by the extractors, and the tx result
+	resTmTx, err := m.clientCtx.Client.Tx(m.ctx, hashBytes, false)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, "transaction not found")
+		}
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to query tx").Error())
+	}
+
+	tmTx := resTmTx.Tx
+
+	// the raw tx is binary-compatible with the Tx proto
+	var tx tx.Tx
+	if err := m.clientCtx.Codec.Unmarshal(tmTx, &tx); err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to decode tx").Error())
+	}
+
+	// the extractors query the tx result by GetTmTxResult, serve them from the cache
+	m.txResultEventsCache.Set(tmTx.Hash(), resTmTx.TxResult.Events)
+
+	involvers := berpctypes.NewMessageInvolversResult()
+	for _, msg := range tx.Body.Messages {
+		var cosmosMsg sdk.Msg
+
+		err := m.clientCtx.Codec.UnpackAny(msg, &cosmosMsg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unpack message").Error())
+		}
+
+		extractor, found := m.messageInvolversExtractors[berpcutils.ProtoMessageName(cosmosMsg)]
+		if !found {
+			continue
+		}
+
+		resInvolvers, err := extractor(cosmosMsg, &tx, tmTx, m.clientCtx)
+		if err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to extract involvers").Error())
+		}
+
+		if _, found := resInvolvers.GenericInvolvers()[berpctypes.MessageSenderSigner]; !found {
+			// if no signer found, try to get it from the signers
+			func() {
+				defer func() {
+					_ = recover() // omit any error
+				}()
+				if len(cosmosMsg.GetSigners()) > 0 {
+					resInvolvers.AddGenericInvolvers(berpctypes.MessageSenderSigner, cosmosMsg.GetSigners()[0].String())
+				}
+			}()
+		}
+
+		involvers.Merge(resInvolvers)
+	}
+
+	involvers.Finalize()
+
+	return involvers, nil
 }
 
-func (m *WasmBackend) GetWasmTransactionByHash(hash string) (berpctypes.GenericBackendResponse, error) {
-	// TODO BE: implement
-	return nil, nil
+// GetWasmTransactionByHash returns a transaction by its hash, with all Wasm messages decoded.
+//   - msgs: every message of the tx, Wasm messages are parsed by the registered message parsers,
+//     execute/instantiate/migrate payloads are provided as-is JSON in `wasmPayload`.
+//   - contractEvents: the `wasm` and `wasm-*` events emitted by contracts, grouped by contract address.
+//   - fee: the fee paid by the tx.
+//   - result: the execution result, includes gas info.
+func (m *WasmBackend) GetWasmTransactionByHash(hashStr string) (berpctypes.GenericBackendResponse, error) {
+	res, err := m.getTxByHash(hashStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := res.Tx
+	txRes := res.TxResponse
+
+	var anyWasmMsg bool
+	msgsInfo := make([]map[string]any, 0)
+	for msgIdx, msg := range tx.Body.Messages {
+		var cosmosMsg sdk.Msg
+
+		err := m.clientCtx.Codec.UnpackAny(msg, &cosmosMsg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unpack message").Error())
+		}
+
+		protoType := berpcutils.ProtoMessageName(cosmosMsg)
+
+		msgInfo := map[string]any{
+			"idx":  msgIdx,
+			"type": protoType,
+		}
+		msgsInfo = append(msgsInfo, msgInfo)
+
+		if isWasmMessage(cosmosMsg) {
+			anyWasmMsg = true
+
+			if payloadType, payload := getWasmMessagePayload(cosmosMsg); len(payloadType) > 0 {
+				wasmPayload := map[string]any{
+					"type": payloadType,
+				}
+				if json.Valid(payload) {
+					// passthrough, prevent precision loss of the numbers
+					wasmPayload["decoded"] = json.RawMessage(payload)
+				} else {
+					wasmPayload["decodeError"] = "invalid JSON payload"
+				}
+				msgInfo["wasmPayload"] = wasmPayload
+			}
+
+			if messageParser, found := m.messageParsers[protoType]; found {
+				parsedContent, err := messageParser(cosmosMsg, uint(msgIdx), tx, txRes)
+				if err != nil {
+					msgInfo["contentError"] = err.Error()
+				} else {
+					// the execute payload is provided as `wasmPayload`
+					delete(parsedContent, "inputMsg")
+					msgInfo["content"] = parsedContent
+				}
+			}
+		}
+
+		{
+			msgContent, err := berpcutils.FromAnyToJsonMap(msg, m.clientCtx.Codec)
+			if err != nil {
+				msgInfo["protoContentError"] = err.Error()
+			} else {
+				msgInfo["protoContent"] = msgContent
+			}
+		}
+	}
+
+	if !anyWasmMsg {
+		return nil, status.Error(codes.InvalidArgument, "not a Wasm transaction")
+	}
+
+	result := berpctypes.GenericBackendResponse{
+		"code":    txRes.Code,
+		"success": txRes.Code == 0,
+		"gas": berpctypes.GenericBackendResponse{
+			"limit": txRes.GasWanted,
+			"used":  txRes.GasUsed,
+		},
+	}
+	if txRes.Code != 0 {
+		result["error"] = txRes.RawLog
+	}
+
+	response := berpctypes.GenericBackendResponse{
+		"height":         txRes.Height,
+		"hash":           txRes.TxHash,
+		"msgs":           msgsInfo,
+		"contractEvents": groupContractEventsByContract(txRes.Events),
+		"result":         result,
+	}
+
+	if tx.AuthInfo != nil && tx.AuthInfo.Fee != nil {
+		fee := berpctypes.GenericBackendResponse{
+			"amount":   berpcutils.CoinsToMap(tx.AuthInfo.Fee.Amount...),
+			"gasLimit": tx.AuthInfo.Fee.GasLimit,
+		}
+		if len(tx.AuthInfo.Fee.Payer) > 0 {
+			fee["payer"] = tx.AuthInfo.Fee.Payer
+		}
+		if len(tx.AuthInfo.Fee.Granter) > 0 {
+			fee["granter"] = tx.AuthInfo.Fee.Granter
+		}
+		response["fee"] = fee
+	}
+
+	if len(tx.Body.Memo) > 0 {
+		response["memo"] = tx.Body.Memo
+	}
+
+	return response, nil
 }
 
+// GetTmTxResult returns the events of the tx result, served from the cache if available.
 func (m *WasmBackend) GetTmTxResult(tmTx tmtypes.Tx) ([]abci.Event, error) {
-	resTxResult, errTxResult := m.clientCtx.Client.Tx(m.ctx, tmTx.Hash(), false)
+	hash := tmTx.Hash()
+
+	if events, found := m.txResultEventsCache.Get(hash); found {
+		return events, nil
+	}
+
+	resTxResult, errTxResult := m.clientCtx.Client.Tx(m.ctx, hash, false)
 	if errTxResult != nil {
 		return nil, errTxResult
 	}
 
+	m.txResultEventsCache.Set(hash, resTxResult.TxResult.Events)
+
 	return resTxResult.TxResult.Events, nil
 }
+
+// getTxByHash returns the tx and its result by hash, via gRPC.
+func (m *WasmBackend) getTxByHash(hashStr string) (*tx.GetTxResponse, error) {
+	hash, err := normalizeTxHash(hashStr)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.queryClient.GetTx(m.ctx, &tx.GetTxRequest{
+		Hash: hash,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if res == nil {
+		return nil, status.Error(codes.NotFound, "transaction not found")
+	}
+
+	return res, nil
+}
+
+// normalizeTxHash validates the tx hash and returns it in normalized form, without 0x prefix.
+func normalizeTxHash(hashStr string) (string, error) {
+	if !patternTxHash.MatchString(hashStr) {
+		return "", berpctypes.ErrBadRequest
+	}
+
+	return berpcutils.NormalizeTransactionHash(hashStr, true)[2:], nil
+}
+
+func isWasmMessage(msg sdk.Msg) bool {
+	return strings.HasPrefix(berpcutils.ProtoMessageName(msg), "cosmwasm.wasm.")
+}
+
+// getWasmMessagePayload returns the JSON payload carried by the execute/instantiate/migrate messages.
+func getWasmMessagePayload(msg sdk.Msg) (payloadType string, payload []byte) {
+	switch wasmMsg := msg.(type) {
+	case *wasmtypes.MsgExecuteContract:
+		return "execute", wasmMsg.Msg
+	case *wasmtypes.MsgInstantiateContract:
+		return "instantiate", wasmMsg.Msg
+	case *wasmtypes.MsgInstantiateContract2:
+		return "instantiate", wasmMsg.Msg
+	case *wasmtypes.MsgMigrateContract:
+		return "migrate", wasmMsg.Msg
+	default:
+		return "", nil
+	}
+}
+
+// groupContractEventsByContract returns the `wasm` and `wasm-*` events, grouped by the emitter contract address.
+func groupContractEventsByContract(events []abci.Event) map[string]berpctypes.TxEvents {
+	res := make(map[string]berpctypes.TxEvents)
+
+	for _, event := range events {
+		if event.Type != wasmtypes.WasmModuleEventType && !strings.HasPrefix(event.Type, wasmtypes.CustomContractEventPrefix) {
+			continue
+		}
+
+		var contractAddress string
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == wasmtypes.AttributeKeyContractAddr {
+				contractAddress = string(attr.Value)
+				break
+			}
+		}
+		if len(contractAddress) < 1 {
+			continue
+		}
+
+		res[contractAddress] = append(res[contractAddress], berpctypes.ConvertTxEvent([]abci.Event{event})...)
+	}
+
+	return res
+}
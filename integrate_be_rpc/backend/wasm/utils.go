@@ -0,0 +1,70 @@
+package wasm
+
+import (
+	"encoding/base64"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"sync"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// getPaginationByKey builds the key-based pagination request.
+// The page key is the base64 encoded `nextPageKey` returned by the previous page, empty for the first page.
+func getPaginationByKey(pageKey string, limit int) (*query.PageRequest, error) {
+	if limit < 0 || limit > maxPageSize {
+		return nil, berpctypes.ErrBadPageSize
+	}
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+
+	var key []byte
+	if len(pageKey) > 0 {
+		var err error
+		key, err = base64.StdEncoding.DecodeString(pageKey)
+		if err != nil {
+			return nil, berpctypes.ErrBadRequest
+		}
+	}
+
+	return &query.PageRequest{
+		Key:   key,
+		Limit: uint64(limit),
+	}, nil
+}
+
+// putNextPageKey puts the base64 encoded next page key into the response, if there is next page.
+func putNextPageKey(res berpctypes.GenericBackendResponse, pagination *query.PageResponse) {
+	if pagination == nil || len(pagination.NextKey) == 0 {
+		return
+	}
+
+	res["nextPageKey"] = base64.StdEncoding.EncodeToString(pagination.NextKey)
+}
+
+// runConcurrently runs the task for each index in [0, n), at most `concurrency` tasks at once,
+// and waits for all of them to complete.
+func runConcurrently(n, concurrency int, task func(i int)) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			task(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -1,12 +1,17 @@
 package wasm
 
 import (
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	berpcbackend "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/backend"
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
+	iberpctypes "github.com/bcdevtools/wasm-block-explorer-rpc-cosmos/integrate_be_rpc/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"math/big"
 )
 
 var _ berpcbackend.RequestInterceptor = (*DefaultRequestInterceptor)(nil)
@@ -28,16 +33,195 @@ func NewDefaultRequestInterceptor(
 	}
 }
 
+// GetTransactionByHash intercepts the Wasm txs, returns the base tx response enriched with:
+//   - contracts: code id, label and CW-20 token info of the contracts involved in the tx.
+//   - cw20Transfers: the CW-20 transfers, with human-formatted amounts.
+//
+// Non-Wasm txs are not intercepted.
+// The base tx response is built by the base backend, which is not intercepted, so no recursion.
 func (m *DefaultRequestInterceptor) GetTransactionByHash(hashStr string) (intercepted bool, response berpctypes.GenericBackendResponse, err error) {
 	// handle WASM txs, otherwise return false
 	intercepted = false
-	// TODO BE: implement
+
+	if !patternTxHash.MatchString(hashStr) {
+		return
+	}
+
+	baseBackend := m.backend.GetBaseBackend()
+	if baseBackend == nil {
+		// base backend not provided, let the base backend handle it without enrichment
+		return
+	}
+
+	hash := berpcutils.NormalizeTransactionHash(hashStr, true)
+
+	resTx, errGetTx := m.beRpcBackend.GetQueryClient().GetTx(m.beRpcBackend.GetContext(), &tx.GetTxRequest{
+		Hash: hash[2:],
+	})
+	if errGetTx != nil || resTx == nil {
+		// let the base backend handle the error
+		return
+	}
+
+	contractAddresses, isWasmTx := m.getContractsInvolvedInWasmTx(resTx.Tx, resTx.TxResponse)
+	if !isWasmTx {
+		return
+	}
+
+	intercepted = true
+
+	response, err = baseBackend.GetTransactionByHash(hashStr)
+	if err != nil {
+		return
+	}
+
+	contractsInfo := make(map[string]berpctypes.GenericBackendResponse)
+	cw20TokensInfo := make(map[string]*iberpctypes.Cw20TokenInfo)
+	for _, contractAddress := range contractAddresses {
+		contractInfo, errQuery := m.backend.QueryContractInfo(contractAddress)
+		if errQuery != nil || contractInfo == nil {
+			continue
+		}
+
+		info := berpctypes.GenericBackendResponse{
+			"codeId": contractInfo.CodeID,
+		}
+		if len(contractInfo.Label) > 0 {
+			info["label"] = contractInfo.Label
+		}
+
+		cw20TokenInfo, errQuery := m.backend.GetCw20TokenMetadata(contractAddress)
+		if errQuery == nil && cw20TokenInfo != nil {
+			cw20TokensInfo[contractAddress] = cw20TokenInfo
+			info["cw20"] = berpctypes.GenericBackendResponse{
+				"name":     cw20TokenInfo.Name,
+				"symbol":   cw20TokenInfo.Symbol,
+				"decimals": cw20TokenInfo.Decimals,
+			}
+		}
+
+		contractsInfo[contractAddress] = info
+	}
+
+	cw20Transfers := make([]berpctypes.GenericBackendResponse, 0)
+	for _, event := range resTx.TxResponse.Events {
+		match, kv := berpcutils.IsEventTypeWithAllAttributes(
+			event,
+			wasmtypes.WasmModuleEventType,
+			wasmtypes.AttributeKeyContractAddr,
+			"action",
+			"from",
+			"to",
+			"amount",
+		)
+		if !match {
+			continue
+		}
+
+		if kv["action"] != "transfer" {
+			continue
+		}
+
+		contractAddress := kv[wasmtypes.AttributeKeyContractAddr]
+		cw20TokenInfo, isCw20 := cw20TokensInfo[contractAddress]
+		if !isCw20 {
+			continue
+		}
+
+		transfer := berpctypes.GenericBackendResponse{
+			"contract": contractAddress,
+			"from":     kv["from"],
+			"to":       kv["to"],
+			"amount":   kv["amount"],
+			"symbol":   cw20TokenInfo.Symbol,
+			"decimals": cw20TokenInfo.Decimals,
+		}
+		if amount, ok := new(big.Int).SetString(kv["amount"], 10); ok {
+			transfer["formattedAmount"] = cw20TokenInfo.FormatAmount(amount)
+		}
+
+		cw20Transfers = append(cw20Transfers, transfer)
+	}
+
+	wasmInfo := berpctypes.GenericBackendResponse{
+		"contracts": contractsInfo,
+	}
+	if len(cw20Transfers) > 0 {
+		wasmInfo["cw20Transfers"] = cw20Transfers
+	}
+
+	response = response.ReInitializeIfNil()
+	response["wasm"] = wasmInfo
+
+	return
+}
+
+// getContractsInvolvedInWasmTx returns the distinct addresses of the contracts those involved in the tx,
+// and whether the tx contains any Wasm message.
+func (m *DefaultRequestInterceptor) getContractsInvolvedInWasmTx(tx *tx.Tx, txResponse *sdk.TxResponse) (contractAddresses []string, isWasmTx bool) {
+	if tx == nil || tx.Body == nil || txResponse == nil {
+		return
+	}
+
+	uniqueContracts := make(map[string]bool)
+	addContract := func(contractAddress string) {
+		if len(contractAddress) < 1 || uniqueContracts[contractAddress] {
+			return
+		}
+		uniqueContracts[contractAddress] = true
+		contractAddresses = append(contractAddresses, contractAddress)
+	}
+
+	for _, msg := range tx.Body.Messages {
+		var cosmosMsg sdk.Msg
+		if errUnpack := m.beRpcBackend.GetClientContext().Codec.UnpackAny(msg, &cosmosMsg); errUnpack != nil {
+			continue
+		}
+
+		if !isWasmMessage(cosmosMsg) {
+			continue
+		}
+
+		isWasmTx = true
+
+		switch wasmMsg := cosmosMsg.(type) {
+		case *wasmtypes.MsgExecuteContract:
+			addContract(wasmMsg.Contract)
+		case *wasmtypes.MsgMigrateContract:
+			addContract(wasmMsg.Contract)
+		case *wasmtypes.MsgUpdateAdmin:
+			addContract(wasmMsg.Contract)
+		case *wasmtypes.MsgClearAdmin:
+			addContract(wasmMsg.Contract)
+		}
+	}
+
+	if !isWasmTx {
+		return
+	}
+
+	for _, event := range txResponse.Events {
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == wasmtypes.AttributeKeyContractAddr {
+				addContract(string(attr.Value))
+			}
+		}
+	}
+
 	return
 }
 
+// GetDenomsInformation appends the CW-20 tokens as `cw20:<contract>` denoms, mapped to their symbol.
 func (m *DefaultRequestInterceptor) GetDenomsInformation() (intercepted, append bool, denoms map[string]string, err error) {
-	intercepted = false
-	// TODO BE: implement
+	intercepted = false // keep the default denoms information
+
+	denoms, err = m.backend.GetCw20Denoms()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get CW-20 denoms")
+		return
+	}
+
+	append = len(denoms) > 0
 	return
 }
 
@@ -123,7 +307,7 @@ func (m *DefaultRequestInterceptor) GetAccount(accountAddressStr string) (interc
 	}
 	response["contract"] = contractInfo
 
-	cw20TokenInfo, err := m.backend.GetCw20ContractInfo(accountAddressStr)
+	cw20TokenInfo, err := m.backend.GetCw20ContractInfo(accountAddressStr, nil)
 	if err == nil && len(cw20TokenInfo) > 0 {
 		for k, v := range cw20TokenInfo {
 			contractInfo[k] = v
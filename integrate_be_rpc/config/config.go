@@ -0,0 +1,195 @@
+package config
+
+import (
+	"errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+)
+
+// WasmBeJsonRpcConfig defines configuration for the Wasm extension of the Block Explorer Json-RPC server.
+type WasmBeJsonRpcConfig struct {
+	// Cw20Contracts defines the known CW-20 contracts, those are always provided as denoms.
+	Cw20Contracts []string `mapstructure:"cw20-contracts"`
+	// ScanCw20Contracts defines if the CW-20 contracts should be discovered by scanning the stored codes.
+	ScanCw20Contracts bool `mapstructure:"scan-cw20-contracts"`
+	// MaxScanCw20Contracts defines the maximum number of CW-20 contracts to be discovered by scanning.
+	MaxScanCw20Contracts int `mapstructure:"max-scan-cw20-contracts"`
+	// MaxScanCw20Codes defines the maximum number of stored codes to be checked when scanning for CW-20 contracts.
+	MaxScanCw20Codes int `mapstructure:"max-scan-cw20-codes"`
+	// Cw20BalanceMaxContracts defines the maximum number of contracts per CW-20 balance query, also applied to CW-721 tokens query.
+	Cw20BalanceMaxContracts int `mapstructure:"cw20-balance-max-contracts"`
+	// Cw20BalanceConcurrency defines the maximum number of contracts to be queried concurrently per CW-20 balance query, also applied to CW-721 tokens query.
+	Cw20BalanceConcurrency int `mapstructure:"cw20-balance-concurrency"`
+	// Cw20BalancesMaxAccounts defines the maximum number of accounts per multi-account CW-20 balances query.
+	Cw20BalancesMaxAccounts int `mapstructure:"cw20-balances-max-accounts"`
+	// Cw20HoldersMaxRank defines the maximum number of holders can be enumerated to be ranked by balance, 0 is disable ranking.
+	Cw20HoldersMaxRank int `mapstructure:"cw20-holders-max-rank"`
+	// Cw20TokenInfoCacheSize defines the maximum number of CW-20 token metadata to be cached.
+	Cw20TokenInfoCacheSize int `mapstructure:"cw20-token-info-cache-size"`
+	// Cw20TokenInfoCacheTTL defines the time-to-live of the cached CW-20 token metadata.
+	Cw20TokenInfoCacheTTL time.Duration `mapstructure:"cw20-token-info-cache-ttl"`
+	// SmartQueryMaxQuerySize defines the maximum size, in bytes, of the JSON query of the smart-query passthrough.
+	SmartQueryMaxQuerySize int `mapstructure:"smart-query-max-query-size"`
+	// SmartQueryMaxResponseSize defines the maximum size, in bytes, of the response of the smart-query passthrough.
+	SmartQueryMaxResponseSize int `mapstructure:"smart-query-max-response-size"`
+	// SmartQueryTimeout defines the timeout of the smart-query passthrough.
+	SmartQueryTimeout time.Duration `mapstructure:"smart-query-timeout"`
+	// SmartQueryAllowedKeys defines the allowed top-level query keys of the smart-query passthrough, empty is allow all.
+	SmartQueryAllowedKeys []string `mapstructure:"smart-query-allowed-keys"`
+	// IpfsGateway defines the HTTP gateway base URL to resolve `ipfs://` URIs, empty is not resolve.
+	IpfsGateway string `mapstructure:"ipfs-gateway"`
+}
+
+// DefaultWasmBeJsonRpcConfig returns Wasm Block Explorer JSON-RPC config with default values
+func DefaultWasmBeJsonRpcConfig() *WasmBeJsonRpcConfig {
+	return &WasmBeJsonRpcConfig{
+		Cw20Contracts:        nil,
+		ScanCw20Contracts:    DefaultScanCw20Contracts,
+		MaxScanCw20Contracts: DefaultMaxScanCw20Contracts,
+		MaxScanCw20Codes:     DefaultMaxScanCw20Codes,
+
+		Cw20BalanceMaxContracts: DefaultCw20BalanceMaxContracts,
+		Cw20BalanceConcurrency:  DefaultCw20BalanceConcurrency,
+		Cw20BalancesMaxAccounts: DefaultCw20BalancesMaxAccounts,
+
+		Cw20HoldersMaxRank: DefaultCw20HoldersMaxRank,
+
+		Cw20TokenInfoCacheSize: DefaultCw20TokenInfoCacheSize,
+		Cw20TokenInfoCacheTTL:  DefaultCw20TokenInfoCacheTTL,
+
+		SmartQueryMaxQuerySize:    DefaultSmartQueryMaxQuerySize,
+		SmartQueryMaxResponseSize: DefaultSmartQueryMaxResponseSize,
+		SmartQueryTimeout:         DefaultSmartQueryTimeout,
+		SmartQueryAllowedKeys:     nil,
+
+		IpfsGateway: "",
+	}
+}
+
+// Validate returns an error if the Wasm JSON-RPC configuration fields are invalid.
+func (c WasmBeJsonRpcConfig) Validate() error {
+	if c.MaxScanCw20Contracts < 0 {
+		return errors.New("Wasm BE-JSON-RPC max scan CW-20 contracts cannot be negative")
+	}
+
+	if c.MaxScanCw20Codes < 0 {
+		return errors.New("Wasm BE-JSON-RPC max scan CW-20 codes cannot be negative")
+	}
+
+	if c.Cw20BalanceMaxContracts <= 0 {
+		return errors.New("Wasm BE-JSON-RPC CW-20 balance max contracts must be positive")
+	}
+
+	if c.Cw20BalanceConcurrency <= 0 {
+		return errors.New("Wasm BE-JSON-RPC CW-20 balance concurrency must be positive")
+	}
+
+	if c.Cw20BalancesMaxAccounts <= 0 {
+		return errors.New("Wasm BE-JSON-RPC CW-20 balances max accounts must be positive")
+	}
+
+	if c.Cw20HoldersMaxRank < 0 {
+		return errors.New("Wasm BE-JSON-RPC CW-20 holders max rank cannot be negative")
+	}
+
+	if c.Cw20TokenInfoCacheSize <= 0 {
+		return errors.New("Wasm BE-JSON-RPC CW-20 token info cache size must be positive")
+	}
+
+	if c.Cw20TokenInfoCacheTTL <= 0 {
+		return errors.New("Wasm BE-JSON-RPC CW-20 token info cache TTL must be positive")
+	}
+
+	if c.SmartQueryMaxQuerySize <= 0 {
+		return errors.New("Wasm BE-JSON-RPC smart query max query size must be positive")
+	}
+
+	if c.SmartQueryMaxResponseSize <= 0 {
+		return errors.New("Wasm BE-JSON-RPC smart query max response size must be positive")
+	}
+
+	if c.SmartQueryTimeout <= 0 {
+		return errors.New("Wasm BE-JSON-RPC smart query timeout must be positive")
+	}
+
+	if c.IpfsGateway != "" && !strings.HasPrefix(c.IpfsGateway, "http://") && !strings.HasPrefix(c.IpfsGateway, "https://") {
+		return errors.New("Wasm BE-JSON-RPC IPFS gateway must be an HTTP(S) URL")
+	}
+
+	return nil
+}
+
+// GetConfig returns a fully parsed WasmBeJsonRpcConfig object.
+// Fields those not set are filled with the default values.
+func GetConfig(v *viper.Viper) (WasmBeJsonRpcConfig, error) {
+	cfg := *DefaultWasmBeJsonRpcConfig()
+
+	if v.IsSet(FlagWasmBeJsonRpcCw20Contracts) {
+		cfg.Cw20Contracts = v.GetStringSlice(FlagWasmBeJsonRpcCw20Contracts)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcScanCw20Contracts) {
+		cfg.ScanCw20Contracts = v.GetBool(FlagWasmBeJsonRpcScanCw20Contracts)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcMaxScanCw20Contracts) {
+		cfg.MaxScanCw20Contracts = v.GetInt(FlagWasmBeJsonRpcMaxScanCw20Contracts)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcMaxScanCw20Codes) {
+		cfg.MaxScanCw20Codes = v.GetInt(FlagWasmBeJsonRpcMaxScanCw20Codes)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcCw20BalanceMaxContracts) {
+		cfg.Cw20BalanceMaxContracts = v.GetInt(FlagWasmBeJsonRpcCw20BalanceMaxContracts)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcCw20BalanceConcurrency) {
+		cfg.Cw20BalanceConcurrency = v.GetInt(FlagWasmBeJsonRpcCw20BalanceConcurrency)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcCw20BalancesMaxAccounts) {
+		cfg.Cw20BalancesMaxAccounts = v.GetInt(FlagWasmBeJsonRpcCw20BalancesMaxAccounts)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcCw20HoldersMaxRank) {
+		cfg.Cw20HoldersMaxRank = v.GetInt(FlagWasmBeJsonRpcCw20HoldersMaxRank)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcCw20TokenInfoCacheSize) {
+		cfg.Cw20TokenInfoCacheSize = v.GetInt(FlagWasmBeJsonRpcCw20TokenInfoCacheSize)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcCw20TokenInfoCacheTTL) {
+		cfg.Cw20TokenInfoCacheTTL = v.GetDuration(FlagWasmBeJsonRpcCw20TokenInfoCacheTTL)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcSmartQueryMaxQuerySize) {
+		cfg.SmartQueryMaxQuerySize = v.GetInt(FlagWasmBeJsonRpcSmartQueryMaxQuerySize)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcSmartQueryMaxResponseSize) {
+		cfg.SmartQueryMaxResponseSize = v.GetInt(FlagWasmBeJsonRpcSmartQueryMaxResponseSize)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcSmartQueryTimeout) {
+		cfg.SmartQueryTimeout = v.GetDuration(FlagWasmBeJsonRpcSmartQueryTimeout)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcSmartQueryAllowedKeys) {
+		cfg.SmartQueryAllowedKeys = v.GetStringSlice(FlagWasmBeJsonRpcSmartQueryAllowedKeys)
+	}
+	if v.IsSet(FlagWasmBeJsonRpcIpfsGateway) {
+		cfg.IpfsGateway = v.GetString(FlagWasmBeJsonRpcIpfsGateway)
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// AddWasmBeJsonRpcFlags add Wasm Block Explorer Json-RPC flags into the cmd
+func AddWasmBeJsonRpcFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(FlagWasmBeJsonRpcCw20Contracts, nil, "define the known CW-20 contracts those always be provided as denoms")
+	cmd.Flags().Bool(FlagWasmBeJsonRpcScanCw20Contracts, DefaultScanCw20Contracts, "define if the CW-20 contracts should be discovered by scanning the stored codes")
+	cmd.Flags().Int(FlagWasmBeJsonRpcMaxScanCw20Contracts, DefaultMaxScanCw20Contracts, "sets maximum number of CW-20 contracts to be discovered by scanning")
+	cmd.Flags().Int(FlagWasmBeJsonRpcMaxScanCw20Codes, DefaultMaxScanCw20Codes, "sets maximum number of stored codes to be checked when scanning for CW-20 contracts")
+	cmd.Flags().Int(FlagWasmBeJsonRpcCw20BalanceMaxContracts, DefaultCw20BalanceMaxContracts, "sets maximum number of contracts per CW-20 balance query (also CW-721 tokens query)")
+	cmd.Flags().Int(FlagWasmBeJsonRpcCw20BalanceConcurrency, DefaultCw20BalanceConcurrency, "sets maximum number of contracts to be queried concurrently per CW-20 balance query (also CW-721 tokens query)")
+	cmd.Flags().Int(FlagWasmBeJsonRpcCw20BalancesMaxAccounts, DefaultCw20BalancesMaxAccounts, "sets maximum number of accounts per multi-account CW-20 balances query")
+	cmd.Flags().Int(FlagWasmBeJsonRpcCw20HoldersMaxRank, DefaultCw20HoldersMaxRank, "sets maximum number of holders can be enumerated to be ranked by balance (0 is disable ranking)")
+	cmd.Flags().Int(FlagWasmBeJsonRpcCw20TokenInfoCacheSize, DefaultCw20TokenInfoCacheSize, "sets maximum number of CW-20 token metadata to be cached")
+	cmd.Flags().Duration(FlagWasmBeJsonRpcCw20TokenInfoCacheTTL, DefaultCw20TokenInfoCacheTTL, "sets time-to-live of the cached CW-20 token metadata")
+	cmd.Flags().Int(FlagWasmBeJsonRpcSmartQueryMaxQuerySize, DefaultSmartQueryMaxQuerySize, "sets maximum size in bytes of the query of the smart-query passthrough")
+	cmd.Flags().Int(FlagWasmBeJsonRpcSmartQueryMaxResponseSize, DefaultSmartQueryMaxResponseSize, "sets maximum size in bytes of the response of the smart-query passthrough")
+	cmd.Flags().Duration(FlagWasmBeJsonRpcSmartQueryTimeout, DefaultSmartQueryTimeout, "sets timeout of the smart-query passthrough")
+	cmd.Flags().StringSlice(FlagWasmBeJsonRpcSmartQueryAllowedKeys, nil, "define the allowed top-level query keys of the smart-query passthrough (empty is allow all)")
+	cmd.Flags().String(FlagWasmBeJsonRpcIpfsGateway, "", "sets HTTP gateway base URL to resolve ipfs:// URIs, eg: https://ipfs.io/ipfs/ (empty is not resolve)")
+}
@@ -0,0 +1,64 @@
+package config
+
+import "time"
+
+const (
+	FlagWasmBeJsonRpcCw20Contracts        = "be.wasm.cw20-contracts"
+	FlagWasmBeJsonRpcScanCw20Contracts    = "be.wasm.scan-cw20-contracts"
+	FlagWasmBeJsonRpcMaxScanCw20Contracts = "be.wasm.max-scan-cw20-contracts"
+	FlagWasmBeJsonRpcMaxScanCw20Codes     = "be.wasm.max-scan-cw20-codes"
+
+	FlagWasmBeJsonRpcCw20BalanceMaxContracts = "be.wasm.cw20-balance-max-contracts"
+	FlagWasmBeJsonRpcCw20BalanceConcurrency  = "be.wasm.cw20-balance-concurrency"
+	FlagWasmBeJsonRpcCw20BalancesMaxAccounts = "be.wasm.cw20-balances-max-accounts"
+
+	FlagWasmBeJsonRpcCw20HoldersMaxRank = "be.wasm.cw20-holders-max-rank"
+
+	FlagWasmBeJsonRpcCw20TokenInfoCacheSize = "be.wasm.cw20-token-info-cache-size"
+	FlagWasmBeJsonRpcCw20TokenInfoCacheTTL  = "be.wasm.cw20-token-info-cache-ttl"
+
+	FlagWasmBeJsonRpcSmartQueryMaxQuerySize    = "be.wasm.smart-query-max-query-size"
+	FlagWasmBeJsonRpcSmartQueryMaxResponseSize = "be.wasm.smart-query-max-response-size"
+	FlagWasmBeJsonRpcSmartQueryTimeout         = "be.wasm.smart-query-timeout"
+	FlagWasmBeJsonRpcSmartQueryAllowedKeys     = "be.wasm.smart-query-allowed-keys"
+
+	FlagWasmBeJsonRpcIpfsGateway = "be.wasm.ipfs-gateway"
+)
+
+const (
+	// DefaultScanCw20Contracts is the default value for discovering CW-20 contracts by scanning the stored codes
+	DefaultScanCw20Contracts = false
+
+	// DefaultMaxScanCw20Contracts is the default maximum number of CW-20 contracts to be discovered by scanning
+	DefaultMaxScanCw20Contracts = 200
+
+	// DefaultMaxScanCw20Codes is the default maximum number of stored codes to be checked when scanning for CW-20 contracts
+	DefaultMaxScanCw20Codes = 500
+
+	// DefaultCw20BalanceMaxContracts is the default maximum number of contracts per CW-20 balance query
+	DefaultCw20BalanceMaxContracts = 50
+
+	// DefaultCw20BalanceConcurrency is the default maximum number of contracts to be queried concurrently per CW-20 balance query
+	DefaultCw20BalanceConcurrency = 8
+
+	// DefaultCw20BalancesMaxAccounts is the default maximum number of accounts per multi-account CW-20 balances query
+	DefaultCw20BalancesMaxAccounts = 30
+
+	// DefaultCw20HoldersMaxRank is the default maximum number of holders can be enumerated to be ranked by balance
+	DefaultCw20HoldersMaxRank = 500
+
+	// DefaultCw20TokenInfoCacheSize is the default maximum number of CW-20 token metadata to be cached
+	DefaultCw20TokenInfoCacheSize = 1000
+
+	// DefaultCw20TokenInfoCacheTTL is the default time-to-live of the cached CW-20 token metadata
+	DefaultCw20TokenInfoCacheTTL = 1 * time.Hour
+
+	// DefaultSmartQueryMaxQuerySize is the default maximum size in bytes of the query of the smart-query passthrough
+	DefaultSmartQueryMaxQuerySize = 4 * 1024
+
+	// DefaultSmartQueryMaxResponseSize is the default maximum size in bytes of the response of the smart-query passthrough
+	DefaultSmartQueryMaxResponseSize = 256 * 1024
+
+	// DefaultSmartQueryTimeout is the default timeout of the smart-query passthrough
+	DefaultSmartQueryTimeout = 5 * time.Second
+)
@@ -9,12 +9,22 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
 
-func RegisterMessageInvolvesExtractorsForWasm(wasmBeRpcBackend wasm.WasmBackendI) {
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgStoreCode{}, ExtractFromMsgStoreCode)
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgInstantiateContract{}, func(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
+// RegisterMessageInvolvesExtractorsForWasm registers the Wasm message involvers extractors into the Block Explorer Json-RPC
+// and returns the registered extractors, keyed by proto message name.
+func RegisterMessageInvolvesExtractorsForWasm(wasmBeRpcBackend wasm.WasmBackendI) map[string]berpctypes.MessageInvolversExtractor {
+	registered := make(map[string]berpctypes.MessageInvolversExtractor)
+
+	register := func(msg sdk.Msg, extractor berpctypes.MessageInvolversExtractor) {
+		berpc.RegisterMessageInvolversExtractor(msg, extractor)
+		registered[berpcutils.ProtoMessageName(msg)] = extractor
+	}
+
+	register(&wasmtypes.MsgStoreCode{}, ExtractFromMsgStoreCode)
+	register(&wasmtypes.MsgInstantiateContract{}, func(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
 		msg := sdkMsg.(*wasmtypes.MsgInstantiateContract)
 
 		events, err := wasmBeRpcBackend.GetTmTxResult(tmTx)
@@ -47,7 +57,7 @@ func RegisterMessageInvolvesExtractorsForWasm(wasmBeRpcBackend wasm.WasmBackendI
 
 		return
 	})
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgInstantiateContract2{}, func(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
+	register(&wasmtypes.MsgInstantiateContract2{}, func(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
 		msg := sdkMsg.(*wasmtypes.MsgInstantiateContract2)
 
 		events, err := wasmBeRpcBackend.GetTmTxResult(tmTx)
@@ -80,8 +90,8 @@ func RegisterMessageInvolvesExtractorsForWasm(wasmBeRpcBackend wasm.WasmBackendI
 
 		return
 	})
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgClearAdmin{}, ExtractFromMsgClearAdmin)
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgExecuteContract{}, func(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
+	register(&wasmtypes.MsgClearAdmin{}, ExtractFromMsgClearAdmin)
+	register(&wasmtypes.MsgExecuteContract{}, func(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
 		msg := sdkMsg.(*wasmtypes.MsgExecuteContract)
 
 		res = berpctypes.NewMessageInvolversResult()
@@ -125,7 +135,7 @@ func RegisterMessageInvolvesExtractorsForWasm(wasmBeRpcBackend wasm.WasmBackendI
 			contractAddr := kv[wasmtypes.AttributeKeyContractAddr]
 			isCw20Contract, foundContract := trackerCw20Contract[contractAddr]
 			if !foundContract {
-				cw20TokenInfo, err := wasmBeRpcBackend.GetCw20TokenInfo(msg.Contract)
+				cw20TokenInfo, err := wasmBeRpcBackend.GetCw20TokenMetadata(contractAddr)
 				if err == nil && len(cw20TokenInfo.Symbol) > 0 {
 					isCw20Contract = true
 				}
@@ -141,13 +151,36 @@ func RegisterMessageInvolvesExtractorsForWasm(wasmBeRpcBackend wasm.WasmBackendI
 			}
 		}
 
+		// the token IDs are provided by the message parser, as `action.nftTransfers`
+		for _, event := range events {
+			contractAddr, involvers := extractCw721TransferInvolvers(event)
+			if len(involvers) < 1 {
+				continue
+			}
+
+			res.AddContractInvolvers(
+				berpctypes.NftInvolvers,
+				berpctypes.ContractAddress(contractAddr),
+				involvers...,
+			)
+		}
+
 		return
 	})
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgIBCCloseChannel{}, ExtractFromMsgIBCCloseChannel)
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgIBCSend{}, ExtractFromMsgIBCSend)
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgMigrateContract{}, ExtractFromMsgMigrateContract)
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgUpdateAdmin{}, ExtractFromMsgUpdateAdmin)
-	berpc.RegisterMessageInvolversExtractor(&wasmtypes.MsgUpdateInstantiateConfig{}, ExtractFromMsgUpdateInstantiateConfig)
+	register(&wasmtypes.MsgIBCCloseChannel{}, ExtractFromMsgIBCCloseChannel)
+	register(&wasmtypes.MsgIBCSend{}, ExtractFromMsgIBCSend)
+	register(&wasmtypes.MsgMigrateContract{}, func(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
+		msg := sdkMsg.(*wasmtypes.MsgMigrateContract)
+
+		// token metadata might be changed by the migration
+		wasmBeRpcBackend.InvalidateCw20TokenMetadata(msg.Contract)
+
+		return ExtractFromMsgMigrateContract(sdkMsg, tx, tmTx, clientCtx)
+	})
+	register(&wasmtypes.MsgUpdateAdmin{}, ExtractFromMsgUpdateAdmin)
+	register(&wasmtypes.MsgUpdateInstantiateConfig{}, ExtractFromMsgUpdateInstantiateConfig)
+
+	return registered
 }
 
 func ExtractFromMsgStoreCode(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
@@ -219,7 +252,51 @@ func ExtractFromMsgUpdateAdmin(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clien
 func ExtractFromMsgUpdateInstantiateConfig(sdkMsg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
 	msg := sdkMsg.(*wasmtypes.MsgUpdateInstantiateConfig)
 
+	res = berpctypes.NewMessageInvolversResult()
+
 	res.AddGenericInvolvers(berpctypes.MessageInvolvers, msg.Sender)
 
 	return
 }
+
+// extractCw721TransferInvolvers returns the accounts involved in the NFT transfer,
+// from the `wasm` event of CW-721 `transfer_nft`, `send_nft` and `mint` actions.
+// The attribute sets are specific to CW-721 so no contract check is needed,
+// CW-20 `mint` is not matched since it does not have `owner` & `token_id`.
+func extractCw721TransferInvolvers(event abci.Event) (contractAddr string, involvers []string) {
+	match, kv := berpcutils.IsEventTypeWithAllAttributes(
+		event,
+		wasmtypes.WasmModuleEventType,
+		wasmtypes.AttributeKeyContractAddr,
+		"action",
+		"token_id",
+	)
+	if !match {
+		return
+	}
+
+	attrs := make(map[string]string)
+	for _, attr := range event.Attributes {
+		attrs[string(attr.Key)] = string(attr.Value)
+	}
+
+	switch kv["action"] {
+	case "transfer_nft", "send_nft":
+		if len(attrs["sender"]) > 0 && len(attrs["recipient"]) > 0 {
+			involvers = []string{attrs["sender"], attrs["recipient"]}
+		}
+	case "mint":
+		if len(attrs["owner"]) > 0 {
+			involvers = []string{attrs["owner"]}
+			if len(attrs["minter"]) > 0 {
+				involvers = append(involvers, attrs["minter"])
+			}
+		}
+	}
+
+	if len(involvers) < 1 {
+		return
+	}
+
+	return kv[wasmtypes.AttributeKeyContractAddr], involvers
+}
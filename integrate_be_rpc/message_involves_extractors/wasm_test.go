@@ -0,0 +1,80 @@
+package message_involves_extractors
+
+import (
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func Test_extractCw721TransferInvolvers(t *testing.T) {
+	newEvent := func(attrs ...string) abci.Event {
+		event := abci.Event{
+			Type: wasmtypes.WasmModuleEventType,
+		}
+		for i := 0; i < len(attrs); i += 2 {
+			event.Attributes = append(event.Attributes, abci.EventAttribute{
+				Key:   []byte(attrs[i]),
+				Value: []byte(attrs[i+1]),
+			})
+		}
+		return event
+	}
+
+	testcases := []struct {
+		name             string
+		event            abci.Event
+		wantContractAddr string
+		wantInvolvers    []string
+	}{
+		{
+			name: "transfer_nft",
+			event: newEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "transfer_nft",
+				"sender", "alice",
+				"recipient", "bob",
+				"token_id", "Token/1",
+			),
+			wantContractAddr: "cw721",
+			wantInvolvers:    []string{"alice", "bob"},
+		},
+		{
+			name: "mint with minter",
+			event: newEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "mint",
+				"minter", "alice",
+				"owner", "bob",
+				"token_id", "1",
+			),
+			wantContractAddr: "cw721",
+			wantInvolvers:    []string{"bob", "alice"},
+		},
+		{
+			name: "burn is not a transfer",
+			event: newEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "burn",
+				"sender", "alice",
+				"token_id", "1",
+			),
+		},
+		{
+			name: "CW-20 mint is not matched",
+			event: newEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw20",
+				"action", "mint",
+				"to", "bob",
+				"amount", "100",
+			),
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			gotContractAddr, gotInvolvers := extractCw721TransferInvolvers(tt.event)
+			require.Equal(t, tt.wantContractAddr, gotContractAddr)
+			require.Equal(t, tt.wantInvolvers, gotInvolvers)
+		})
+	}
+}
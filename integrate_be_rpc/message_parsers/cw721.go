@@ -0,0 +1,209 @@
+package message_parsers
+
+import (
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// CW-721 actions, emitted as the `action` attribute of the `wasm` event
+// and used as the top-level key of the execute payload.
+const (
+	cw721ActionTransferNft = "transfer_nft"
+	cw721ActionSendNft     = "send_nft"
+	cw721ActionMint        = "mint"
+	cw721ActionBurn        = "burn"
+	cw721ActionApprove     = "approve"
+	cw721ActionRevoke      = "revoke"
+	cw721ActionApproveAll  = "approve_all"
+	cw721ActionRevokeAll   = "revoke_all"
+)
+
+// parseCw721Event parses the `wasm` event emitted by CW-721 contracts.
+// Transfer-like actions (transfer_nft, send_nft, mint, burn) are returned as `nftTransfer`,
+// approval actions (approve, revoke, approve_all, revoke_all) are returned as `nftApproval`.
+// CW-20 actions of the same name (mint, burn) are not matched because they do not have `token_id`.
+func parseCw721Event(event abci.Event) (nftTransfer, nftApproval berpctypes.GenericBackendResponse) {
+	if event.Type != wasmtypes.WasmModuleEventType {
+		return
+	}
+
+	attrs := make(map[string]string)
+	for _, attr := range event.Attributes {
+		attrs[string(attr.Key)] = string(attr.Value)
+	}
+
+	has := func(keys ...string) bool {
+		for _, key := range keys {
+			if _, found := attrs[key]; !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	action := attrs["action"]
+	contractAddress := attrs[wasmtypes.AttributeKeyContractAddr]
+	if len(contractAddress) < 1 {
+		return
+	}
+
+	switch action {
+	case cw721ActionTransferNft, cw721ActionSendNft:
+		if has("sender", "recipient", "token_id") {
+			nftTransfer = berpctypes.GenericBackendResponse{
+				"action":   action,
+				"contract": contractAddress,
+				"tokenId":  attrs["token_id"],
+				"from":     attrs["sender"],
+				"to":       attrs["recipient"],
+			}
+		}
+	case cw721ActionMint:
+		if has("owner", "token_id") {
+			nftTransfer = berpctypes.GenericBackendResponse{
+				"action":   action,
+				"contract": contractAddress,
+				"tokenId":  attrs["token_id"],
+				"to":       attrs["owner"],
+			}
+			if minter, found := attrs["minter"]; found {
+				nftTransfer["minter"] = minter
+			}
+		}
+	case cw721ActionBurn:
+		if has("sender", "token_id") {
+			nftTransfer = berpctypes.GenericBackendResponse{
+				"action":   action,
+				"contract": contractAddress,
+				"tokenId":  attrs["token_id"],
+				"from":     attrs["sender"],
+			}
+		}
+	case cw721ActionApprove, cw721ActionRevoke:
+		if has("sender", "spender", "token_id") {
+			nftApproval = berpctypes.GenericBackendResponse{
+				"action":   action,
+				"contract": contractAddress,
+				"tokenId":  attrs["token_id"],
+				"owner":    attrs["sender"],
+				"spender":  attrs["spender"],
+			}
+		}
+	case cw721ActionApproveAll, cw721ActionRevokeAll:
+		if has("sender", "operator") {
+			nftApproval = berpctypes.GenericBackendResponse{
+				"action":   action,
+				"contract": contractAddress,
+				"owner":    attrs["sender"],
+				"operator": attrs["operator"],
+			}
+		}
+	}
+
+	return
+}
+
+// parseCw721ExecutePayload parses the CW-721 execute payload into the same shape as parseCw721Event does,
+// used to describe the message when no event available, e.g. failed tx.
+func parseCw721ExecutePayload(contractAddress string, inputMsg map[string]any) berpctypes.GenericBackendResponse {
+	if len(inputMsg) != 1 {
+		return nil
+	}
+
+	for action, content := range inputMsg {
+		fields, ok := content.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		getString := func(key string) string {
+			value, _ := fields[key].(string)
+			return value
+		}
+
+		res := berpctypes.GenericBackendResponse{
+			"action":   action,
+			"contract": contractAddress,
+		}
+
+		switch action {
+		case cw721ActionTransferNft:
+			res["to"] = getString("recipient")
+		case cw721ActionSendNft:
+			res["to"] = getString("contract")
+		case cw721ActionMint:
+			res["to"] = getString("owner")
+		case cw721ActionBurn:
+		case cw721ActionApprove, cw721ActionRevoke:
+			res["spender"] = getString("spender")
+		case cw721ActionApproveAll, cw721ActionRevokeAll:
+			res["operator"] = getString("operator")
+			if len(res["operator"].(string)) < 1 {
+				return nil
+			}
+			return res
+		default:
+			return nil
+		}
+
+		tokenId := getString("token_id")
+		if len(tokenId) < 1 {
+			return nil
+		}
+		res["tokenId"] = tokenId
+
+		return res
+	}
+
+	return nil
+}
+
+// writeCw721ActionSentence writes the friendly sentence describing the CW-721 action,
+// like "X transferred NFT #42 of collection Y to Z".
+func writeCw721ActionSentence(rb berpctypes.FriendlyResponseContentBuilderI, sender string, nftAction berpctypes.GenericBackendResponse) {
+	getString := func(key string) string {
+		value, _ := nftAction[key].(string)
+		return value
+	}
+
+	action := getString("action")
+	contractAddress := getString("contract")
+	tokenId := getString("tokenId")
+
+	rb.WriteAddress(sender)
+
+	switch action {
+	case cw721ActionTransferNft:
+		rb.WriteText(" transferred NFT #").WriteText(tokenId).
+			WriteText(" of collection ").WriteAddress(contractAddress).
+			WriteText(" to ").WriteAddress(getString("to"))
+	case cw721ActionSendNft:
+		rb.WriteText(" sent NFT #").WriteText(tokenId).
+			WriteText(" of collection ").WriteAddress(contractAddress).
+			WriteText(" to contract ").WriteAddress(getString("to"))
+	case cw721ActionMint:
+		rb.WriteText(" minted NFT #").WriteText(tokenId).
+			WriteText(" of collection ").WriteAddress(contractAddress).
+			WriteText(" to ").WriteAddress(getString("to"))
+	case cw721ActionBurn:
+		rb.WriteText(" burned NFT #").WriteText(tokenId).
+			WriteText(" of collection ").WriteAddress(contractAddress)
+	case cw721ActionApprove:
+		rb.WriteText(" approved ").WriteAddress(getString("spender")).
+			WriteText(" to transfer NFT #").WriteText(tokenId).
+			WriteText(" of collection ").WriteAddress(contractAddress)
+	case cw721ActionRevoke:
+		rb.WriteText(" revoked approval of ").WriteAddress(getString("spender")).
+			WriteText(" on NFT #").WriteText(tokenId).
+			WriteText(" of collection ").WriteAddress(contractAddress)
+	case cw721ActionApproveAll:
+		rb.WriteText(" approved operator ").WriteAddress(getString("operator")).
+			WriteText(" for all NFTs of collection ").WriteAddress(contractAddress)
+	case cw721ActionRevokeAll:
+		rb.WriteText(" revoked operator ").WriteAddress(getString("operator")).
+			WriteText(" on all NFTs of collection ").WriteAddress(contractAddress)
+	default:
+		rb.WriteText(" executes contract ").WriteAddress(contractAddress)
+	}
+}
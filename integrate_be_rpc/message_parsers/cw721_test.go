@@ -0,0 +1,246 @@
+package message_parsers
+
+import (
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func Test_parseCw721Event(t *testing.T) {
+	testcases := []struct {
+		name            string
+		event           abci.Event
+		wantNftTransfer berpctypes.GenericBackendResponse
+		wantNftApproval berpctypes.GenericBackendResponse
+	}{
+		{
+			name: "transfer_nft",
+			event: newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "transfer_nft",
+				"sender", "alice",
+				"recipient", "bob",
+				"token_id", "1",
+			),
+			wantNftTransfer: berpctypes.GenericBackendResponse{
+				"action":   "transfer_nft",
+				"contract": "cw721",
+				"tokenId":  "1",
+				"from":     "alice",
+				"to":       "bob",
+			},
+		},
+		{
+			name: "mint with minter",
+			event: newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "mint",
+				"minter", "alice",
+				"owner", "bob",
+				"token_id", "1",
+			),
+			wantNftTransfer: berpctypes.GenericBackendResponse{
+				"action":   "mint",
+				"contract": "cw721",
+				"tokenId":  "1",
+				"to":       "bob",
+				"minter":   "alice",
+			},
+		},
+		{
+			name: "burn",
+			event: newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "burn",
+				"sender", "alice",
+				"token_id", "1",
+			),
+			wantNftTransfer: berpctypes.GenericBackendResponse{
+				"action":   "burn",
+				"contract": "cw721",
+				"tokenId":  "1",
+				"from":     "alice",
+			},
+		},
+		{
+			name: "approve",
+			event: newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "approve",
+				"sender", "alice",
+				"spender", "bob",
+				"token_id", "1",
+			),
+			wantNftApproval: berpctypes.GenericBackendResponse{
+				"action":   "approve",
+				"contract": "cw721",
+				"tokenId":  "1",
+				"owner":    "alice",
+				"spender":  "bob",
+			},
+		},
+		{
+			name: "approve_all",
+			event: newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "approve_all",
+				"sender", "alice",
+				"operator", "bob",
+			),
+			wantNftApproval: berpctypes.GenericBackendResponse{
+				"action":   "approve_all",
+				"contract": "cw721",
+				"owner":    "alice",
+				"operator": "bob",
+			},
+		},
+		{
+			name: "CW-20 mint is not matched",
+			event: newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw20",
+				"action", "mint",
+				"to", "bob",
+				"amount", "100",
+			),
+		},
+		{
+			name: "missing contract address",
+			event: newTestWasmEvent(
+				"action", "transfer_nft",
+				"sender", "alice",
+				"recipient", "bob",
+				"token_id", "1",
+			),
+		},
+		{
+			name: "not wasm event",
+			event: abci.Event{
+				Type: "transfer",
+				Attributes: []abci.EventAttribute{
+					{Key: []byte(wasmtypes.AttributeKeyContractAddr), Value: []byte("cw721")},
+					{Key: []byte("action"), Value: []byte("burn")},
+					{Key: []byte("sender"), Value: []byte("alice")},
+					{Key: []byte("token_id"), Value: []byte("1")},
+				},
+			},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			gotNftTransfer, gotNftApproval := parseCw721Event(tt.event)
+			require.Equal(t, tt.wantNftTransfer, gotNftTransfer)
+			require.Equal(t, tt.wantNftApproval, gotNftApproval)
+		})
+	}
+}
+
+func Test_parseCw721ExecutePayload(t *testing.T) {
+	testcases := []struct {
+		name     string
+		inputMsg map[string]any
+		want     berpctypes.GenericBackendResponse
+	}{
+		{
+			name: "transfer_nft",
+			inputMsg: map[string]any{
+				"transfer_nft": map[string]any{"recipient": "bob", "token_id": "1"},
+			},
+			want: berpctypes.GenericBackendResponse{
+				"action":   "transfer_nft",
+				"contract": "cw721",
+				"to":       "bob",
+				"tokenId":  "1",
+			},
+		},
+		{
+			name: "send_nft",
+			inputMsg: map[string]any{
+				"send_nft": map[string]any{"contract": "receiver", "token_id": "1", "msg": "e30="},
+			},
+			want: berpctypes.GenericBackendResponse{
+				"action":   "send_nft",
+				"contract": "cw721",
+				"to":       "receiver",
+				"tokenId":  "1",
+			},
+		},
+		{
+			name: "burn",
+			inputMsg: map[string]any{
+				"burn": map[string]any{"token_id": "1"},
+			},
+			want: berpctypes.GenericBackendResponse{
+				"action":   "burn",
+				"contract": "cw721",
+				"tokenId":  "1",
+			},
+		},
+		{
+			name: "revoke_all",
+			inputMsg: map[string]any{
+				"revoke_all": map[string]any{"operator": "bob"},
+			},
+			want: berpctypes.GenericBackendResponse{
+				"action":   "revoke_all",
+				"contract": "cw721",
+				"operator": "bob",
+			},
+		},
+		{
+			name: "approve_all without operator",
+			inputMsg: map[string]any{
+				"approve_all": map[string]any{},
+			},
+			want: nil,
+		},
+		{
+			name: "missing token id",
+			inputMsg: map[string]any{
+				"transfer_nft": map[string]any{"recipient": "bob"},
+			},
+			want: nil,
+		},
+		{
+			name: "CW-20 burn without token id",
+			inputMsg: map[string]any{
+				"burn": map[string]any{"amount": "100"},
+			},
+			want: nil,
+		},
+		{
+			name: "unknown action",
+			inputMsg: map[string]any{
+				"increase_allowance": map[string]any{"spender": "bob", "amount": "100"},
+			},
+			want: nil,
+		},
+		{
+			name: "multiple keys",
+			inputMsg: map[string]any{
+				"burn":         map[string]any{"token_id": "1"},
+				"transfer_nft": map[string]any{"recipient": "bob", "token_id": "1"},
+			},
+			want: nil,
+		},
+		{
+			name: "content is not object",
+			inputMsg: map[string]any{
+				"burn": "1",
+			},
+			want: nil,
+		},
+		{
+			name:     "nil",
+			inputMsg: nil,
+			want:     nil,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCw721ExecutePayload("cw721", tt.inputMsg)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
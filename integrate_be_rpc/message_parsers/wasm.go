@@ -8,21 +8,48 @@ import (
 	berpc "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc"
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
 	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
+	"github.com/bcdevtools/wasm-block-explorer-rpc-cosmos/integrate_be_rpc/backend/wasm"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"math/big"
 )
 
-func RegisterMessageParsersForWasm() {
-	berpc.RegisterMessageParser(&wasmtypes.MsgStoreCode{}, ParseMsgStoreCode)
-	berpc.RegisterMessageParser(&wasmtypes.MsgInstantiateContract{}, ParseMsgInstantiateContract)
-	berpc.RegisterMessageParser(&wasmtypes.MsgInstantiateContract2{}, ParseMsgInstantiateContract2)
-	berpc.RegisterMessageParser(&wasmtypes.MsgClearAdmin{}, ParseMsgClearAdmin)
-	berpc.RegisterMessageParser(&wasmtypes.MsgExecuteContract{}, ParseMsgExecuteContract)
-	berpc.RegisterMessageParser(&wasmtypes.MsgIBCCloseChannel{}, ParseMsgIBCCloseChannel)
-	berpc.RegisterMessageParser(&wasmtypes.MsgIBCSend{}, ParseMsgIBCSend)
-	berpc.RegisterMessageParser(&wasmtypes.MsgMigrateContract{}, ParseMsgMigrateContract)
-	berpc.RegisterMessageParser(&wasmtypes.MsgUpdateAdmin{}, ParseMsgUpdateAdmin)
-	berpc.RegisterMessageParser(&wasmtypes.MsgUpdateInstantiateConfig{}, ParseMsgUpdateInstantiateConfig)
+// RegisterMessageParsersForWasm registers the Wasm message parsers into the Block Explorer Json-RPC
+// and returns the registered parsers, keyed by proto message name.
+func RegisterMessageParsersForWasm(wasmBeRpcBackend wasm.WasmBackendI) map[string]berpctypes.MessageParser {
+	registered := make(map[string]berpctypes.MessageParser)
+
+	register := func(msg sdk.Msg, parser berpctypes.MessageParser) {
+		berpc.RegisterMessageParser(msg, parser)
+		registered[berpcutils.ProtoMessageName(msg)] = parser
+	}
+
+	register(&wasmtypes.MsgStoreCode{}, ParseMsgStoreCode)
+	register(&wasmtypes.MsgInstantiateContract{}, ParseMsgInstantiateContract)
+	register(&wasmtypes.MsgInstantiateContract2{}, ParseMsgInstantiateContract2)
+	register(&wasmtypes.MsgClearAdmin{}, ParseMsgClearAdmin)
+	register(&wasmtypes.MsgExecuteContract{}, func(sdkMsg sdk.Msg, msgIdx uint, tx *tx.Tx, txResponse *sdk.TxResponse) (res berpctypes.GenericBackendResponse, err error) {
+		res, err = ParseMsgExecuteContract(sdkMsg, msgIdx, tx, txResponse)
+		if err != nil {
+			return
+		}
+
+		if action, ok := res["action"].(berpctypes.GenericBackendResponse); ok {
+			if transfers, ok := action["transfers"].([]berpctypes.GenericBackendResponse); ok {
+				putCw20TransfersFormattedAmount(transfers, wasmBeRpcBackend)
+			}
+		}
+
+		return
+	})
+	register(&wasmtypes.MsgIBCCloseChannel{}, ParseMsgIBCCloseChannel)
+	register(&wasmtypes.MsgIBCSend{}, ParseMsgIBCSend)
+	register(&wasmtypes.MsgMigrateContract{}, ParseMsgMigrateContract)
+	register(&wasmtypes.MsgUpdateAdmin{}, ParseMsgUpdateAdmin)
+	register(&wasmtypes.MsgUpdateInstantiateConfig{}, ParseMsgUpdateInstantiateConfig)
+
+	return registered
 }
 
 func ParseMsgStoreCode(sdkMsg sdk.Msg, msgIdx uint, tx *tx.Tx, txResponse *sdk.TxResponse) (res berpctypes.GenericBackendResponse, err error) {
@@ -203,18 +230,28 @@ func ParseMsgExecuteContract(sdkMsg sdk.Msg, msgIdx uint, tx *tx.Tx, txResponse
 		"funds":    berpcutils.CoinsToMap(msg.Funds...),
 	}
 
+	var inputMsg map[string]any
 	if len(msg.Msg) > 0 {
-		var unmarshalledMsg map[string]any
-		err = json.Unmarshal(msg.Msg, &unmarshalledMsg)
+		err = json.Unmarshal(msg.Msg, &inputMsg)
 		if err == nil {
-			res["inputMsg"] = unmarshalledMsg
+			res["inputMsg"] = inputMsg
 		}
 	}
 
 	// TODO BE: implement error message if any
 
 	transfers := make([]berpctypes.GenericBackendResponse, 0)
-	for _, event := range txResponse.Events {
+	nftTransfers := make([]berpctypes.GenericBackendResponse, 0)
+	nftApprovals := make([]berpctypes.GenericBackendResponse, 0)
+	for _, event := range getMessageEvents(msgIdx, tx, txResponse) {
+		if nftTransfer, nftApproval := parseCw721Event(event); nftTransfer != nil {
+			nftTransfers = append(nftTransfers, nftTransfer)
+			continue
+		} else if nftApproval != nil {
+			nftApprovals = append(nftApprovals, nftApproval)
+			continue
+		}
+
 		match, kv := berpcutils.IsEventTypeWithAllAttributes(
 			event,
 			wasmtypes.WasmModuleEventType,
@@ -233,9 +270,10 @@ func ParseMsgExecuteContract(sdkMsg sdk.Msg, msgIdx uint, tx *tx.Tx, txResponse
 		}
 
 		transfers = append(transfers, berpctypes.GenericBackendResponse{
-			"from":   kv["from"],
-			"to":     kv["to"],
-			"amount": kv["amount"],
+			"contract": kv[wasmtypes.AttributeKeyContractAddr],
+			"from":     kv["from"],
+			"to":       kv["to"],
+			"amount":   kv["amount"],
 		})
 	}
 
@@ -243,18 +281,104 @@ func ParseMsgExecuteContract(sdkMsg sdk.Msg, msgIdx uint, tx *tx.Tx, txResponse
 	if len(transfers) > 0 {
 		action["transfers"] = transfers
 	}
+	if len(nftTransfers) > 0 {
+		action["nftTransfers"] = nftTransfers
+	}
+	if len(nftApprovals) > 0 {
+		action["nftApprovals"] = nftApprovals
+	}
 
 	res["action"] = action
 
-	berpctypes.NewFriendlyResponseContentBuilder().
-		WriteAddress(msg.Sender).
-		WriteText(" executes contract ").
-		WriteAddress(msg.Contract).
-		BuildIntoResponse(res)
+	// describe the NFT action if it is the only one, otherwise the generic sentence
+	var nftAction berpctypes.GenericBackendResponse
+	if len(nftTransfers)+len(nftApprovals) == 1 {
+		if len(nftTransfers) == 1 {
+			nftAction = nftTransfers[0]
+		} else {
+			nftAction = nftApprovals[0]
+		}
+	} else if len(nftTransfers)+len(nftApprovals) == 0 && len(transfers) == 0 {
+		nftAction = parseCw721ExecutePayload(msg.Contract, inputMsg)
+	}
+
+	rb := berpctypes.NewFriendlyResponseContentBuilder()
+	if nftAction != nil {
+		writeCw721ActionSentence(rb, msg.Sender, nftAction)
+	} else {
+		rb.WriteAddress(msg.Sender).
+			WriteText(" executes contract ").
+			WriteAddress(msg.Contract)
+	}
+	rb.BuildIntoResponse(res)
 
 	return
 }
 
+// getMessageEvents returns the events emitted by the message at the index.
+// The tx events are split at the `message` event with `action` of the message type, which is emitted by the SDK
+// right before the events of each message. The message logs are not used because the events there are flattened,
+// the events of the same type are merged into one.
+// If the events can not be split, e.g. failed tx, all the tx events are returned.
+func getMessageEvents(msgIdx uint, tx *tx.Tx, txResponse *sdk.TxResponse) []abci.Event {
+	if tx == nil || tx.Body == nil || int(msgIdx) >= len(tx.Body.Messages) {
+		return txResponse.Events
+	}
+
+	msgs := tx.Body.Messages
+
+	starts := make([]int, 0, len(msgs))
+	for i, event := range txResponse.Events {
+		if len(starts) == len(msgs) {
+			break
+		}
+
+		if event.Type != sdk.EventTypeMessage || len(event.Attributes) < 1 {
+			continue
+		}
+
+		firstAttr := event.Attributes[0]
+		if string(firstAttr.Key) == sdk.AttributeKeyAction && string(firstAttr.Value) == msgs[len(starts)].TypeUrl {
+			starts = append(starts, i)
+		}
+	}
+
+	if len(starts) != len(msgs) {
+		return txResponse.Events
+	}
+
+	end := len(txResponse.Events)
+	if int(msgIdx)+1 < len(starts) {
+		end = starts[msgIdx+1]
+	}
+
+	return txResponse.Events[starts[msgIdx]:end]
+}
+
+// putCw20TransfersFormattedAmount puts the token symbol and the human-readable amount into each CW-20 transfer,
+// transfers of the tokens those metadata could not be fetched are kept as is.
+func putCw20TransfersFormattedAmount(transfers []berpctypes.GenericBackendResponse, wasmBeRpcBackend wasm.WasmBackendI) {
+	for _, transfer := range transfers {
+		contractAddress, _ := transfer["contract"].(string)
+		if len(contractAddress) < 1 {
+			continue
+		}
+
+		cw20TokenInfo, err := wasmBeRpcBackend.GetCw20TokenMetadata(contractAddress)
+		if err != nil || cw20TokenInfo == nil {
+			continue
+		}
+
+		transfer["symbol"] = cw20TokenInfo.Symbol
+		transfer["decimals"] = cw20TokenInfo.Decimals
+
+		amountStr, _ := transfer["amount"].(string)
+		if amount, ok := new(big.Int).SetString(amountStr, 10); ok {
+			transfer["formattedAmount"] = cw20TokenInfo.FormatAmount(amount)
+		}
+	}
+}
+
 func ParseMsgIBCCloseChannel(sdkMsg sdk.Msg, msgIdx uint, tx *tx.Tx, txResponse *sdk.TxResponse) (res berpctypes.GenericBackendResponse, err error) {
 	msg := sdkMsg.(*wasmtypes.MsgIBCCloseChannel)
 
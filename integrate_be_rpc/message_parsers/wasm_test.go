@@ -0,0 +1,198 @@
+package message_parsers
+
+import (
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"testing"
+)
+
+func newTestWasmEvent(attrs ...string) abci.Event {
+	return newTestEvent(wasmtypes.WasmModuleEventType, attrs...)
+}
+
+func newTestTx(t *testing.T, msgs ...sdk.Msg) *tx.Tx {
+	anys := make([]*codectypes.Any, len(msgs))
+	for i, msg := range msgs {
+		any, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		anys[i] = any
+	}
+	return &tx.Tx{
+		Body: &tx.TxBody{
+			Messages: anys,
+		},
+	}
+}
+
+func newTestEvent(eventType string, attrs ...string) abci.Event {
+	event := abci.Event{
+		Type: eventType,
+	}
+	for i := 0; i < len(attrs); i += 2 {
+		event.Attributes = append(event.Attributes, abci.EventAttribute{
+			Key:   []byte(attrs[i]),
+			Value: []byte(attrs[i+1]),
+		})
+	}
+	return event
+}
+
+// newTestMessageActionEvent returns the `message` event emitted by the SDK right before the events of each message.
+func newTestMessageActionEvent(msg sdk.Msg) abci.Event {
+	return newTestEvent(sdk.EventTypeMessage, sdk.AttributeKeyAction, sdk.MsgTypeURL(msg))
+}
+
+func Test_ParseMsgExecuteContract_MultipleMessages(t *testing.T) {
+	msgs := []sdk.Msg{
+		&wasmtypes.MsgExecuteContract{
+			Sender:   "a",
+			Contract: "router",
+			Msg:      []byte(`{"swap":{}}`),
+		},
+		&wasmtypes.MsgExecuteContract{
+			Sender:   "a",
+			Contract: "cw721",
+			Msg:      []byte(`{"transfer_nft":{"recipient":"b","token_id":"42"}}`),
+		},
+	}
+
+	txResponse := &sdk.TxResponse{
+		Events: []abci.Event{
+			newTestEvent("tx", "fee", "1stake"),
+			// message 0, the router performs 2 transfers on 2 tokens
+			newTestMessageActionEvent(msgs[0]),
+			newTestEvent(sdk.EventTypeMessage, sdk.AttributeKeyModule, "wasm", sdk.AttributeKeySender, "a"),
+			newTestEvent(wasmtypes.EventTypeExecute, wasmtypes.AttributeKeyContractAddr, "router"),
+			newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "tokA",
+				"action", "transfer",
+				"from", "a",
+				"to", "b",
+				"amount", "1",
+			),
+			newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "tokB",
+				"action", "transfer",
+				"from", "b",
+				"to", "c",
+				"amount", "2",
+			),
+			// message 1
+			newTestMessageActionEvent(msgs[1]),
+			newTestWasmEvent(
+				wasmtypes.AttributeKeyContractAddr, "cw721",
+				"action", "transfer_nft",
+				"sender", "a",
+				"recipient", "b",
+				"token_id", "42",
+			),
+		},
+	}
+
+	tx := newTestTx(t, msgs...)
+
+	res, err := ParseMsgExecuteContract(msgs[0], 0, tx, txResponse)
+	require.NoError(t, err)
+	action := res["action"].(berpctypes.GenericBackendResponse)
+	require.Equal(t, []berpctypes.GenericBackendResponse{
+		{
+			"contract": "tokA",
+			"from":     "a",
+			"to":       "b",
+			"amount":   "1",
+		},
+		{
+			"contract": "tokB",
+			"from":     "b",
+			"to":       "c",
+			"amount":   "2",
+		},
+	}, action["transfers"])
+	require.NotContains(t, action, "nftTransfers")
+
+	res, err = ParseMsgExecuteContract(msgs[1], 1, tx, txResponse)
+	require.NoError(t, err)
+	action = res["action"].(berpctypes.GenericBackendResponse)
+	require.NotContains(t, action, "transfers")
+	require.Equal(t, []berpctypes.GenericBackendResponse{
+		{
+			"action":   "transfer_nft",
+			"contract": "cw721",
+			"tokenId":  "42",
+			"from":     "a",
+			"to":       "b",
+		},
+	}, action["nftTransfers"])
+}
+
+func Test_getMessageEvents(t *testing.T) {
+	msg0 := &wasmtypes.MsgExecuteContract{Contract: "c0"}
+	msg1 := &wasmtypes.MsgExecuteContract{Contract: "c1"}
+
+	anteEvent := newTestEvent("tx", "fee", "1stake")
+	action0 := newTestMessageActionEvent(msg0)
+	action1 := newTestMessageActionEvent(msg1)
+	handlerMessageEvent := newTestEvent(sdk.EventTypeMessage, sdk.AttributeKeyModule, "wasm")
+	event0a := newTestWasmEvent("k", "0a")
+	event0b := newTestWasmEvent("k", "0b")
+	event1 := newTestWasmEvent("k", "1")
+
+	events := []abci.Event{anteEvent, action0, handlerMessageEvent, event0a, event0b, action1, event1}
+
+	testcases := []struct {
+		name   string
+		msgIdx uint
+		tx     *tx.Tx
+		events []abci.Event
+		want   []abci.Event
+	}{
+		{
+			name:   "first message",
+			msgIdx: 0,
+			tx:     newTestTx(t, msg0, msg1),
+			events: events,
+			want:   []abci.Event{action0, handlerMessageEvent, event0a, event0b},
+		},
+		{
+			name:   "last message",
+			msgIdx: 1,
+			tx:     newTestTx(t, msg0, msg1),
+			events: events,
+			want:   []abci.Event{action1, event1},
+		},
+		{
+			name:   "single message",
+			msgIdx: 0,
+			tx:     newTestTx(t, msg0),
+			events: []abci.Event{anteEvent, action0, event0a},
+			want:   []abci.Event{action0, event0a},
+		},
+		{
+			name:   "failed tx, fallback to tx events",
+			msgIdx: 1,
+			tx:     newTestTx(t, msg0, msg1),
+			events: []abci.Event{anteEvent},
+			want:   []abci.Event{anteEvent},
+		},
+		{
+			name:   "message index out of range, fallback to tx events",
+			msgIdx: 2,
+			tx:     newTestTx(t, msg0, msg1),
+			events: events,
+			want:   events,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getMessageEvents(tt.msgIdx, tt.tx, &sdk.TxResponse{
+				Events: tt.events,
+			})
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package wasm
+
+import (
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+)
+
+func (api *API) GetCodeInfo(codeId uint64) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCodeInfo")
+	return api.backend.GetCodeInfo(codeId)
+}
+
+func (api *API) ListCodes(pageKey *string, limit *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_listCodes")
+	return api.backend.ListCodes(getPageKey(pageKey), getLimit(limit))
+}
+
+func (api *API) GetContractsByCode(codeId uint64, pageKey *string, limit *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getContractsByCode")
+	return api.backend.GetContractsByCode(codeId, getPageKey(pageKey), getLimit(limit))
+}
@@ -0,0 +1,31 @@
+package wasm
+
+import (
+	"encoding/json"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+)
+
+func (api *API) GetContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getContractInfo")
+	return api.backend.GetContractInfo(contractAddress)
+}
+
+func (api *API) GetContractHistory(contractAddress string, pageKey *string, limit *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getContractHistory")
+	return api.backend.GetContractHistory(contractAddress, getPageKey(pageKey), getLimit(limit))
+}
+
+func (api *API) QuerySmartContract(contractAddress string, query json.RawMessage, height *int64) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_querySmartContract")
+	return api.backend.QuerySmartContract(contractAddress, query, height)
+}
+
+func (api *API) GetRawContractState(contractAddress string, keyHex string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getRawContractState")
+	return api.backend.GetRawContractState(contractAddress, keyHex)
+}
+
+func (api *API) GetAllContractState(contractAddress string, pageKey *string, limit *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getAllContractState")
+	return api.backend.GetAllContractState(contractAddress, getPageKey(pageKey), getLimit(limit))
+}
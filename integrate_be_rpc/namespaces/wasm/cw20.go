@@ -4,12 +4,57 @@ import (
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
 )
 
-func (api *API) GetCw20ContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error) {
+func (api *API) GetCw20ContractInfo(contractAddress string, height *int64) (berpctypes.GenericBackendResponse, error) {
 	api.logger.Debug("wasm_getCw20ContractInfo")
-	return api.backend.GetCw20ContractInfo(contractAddress)
+	return api.backend.GetCw20ContractInfo(contractAddress, height)
 }
 
-func (api *API) GetCw20Balance(accountAddress string, contractAddresses []string) (berpctypes.GenericBackendResponse, error) {
+func (api *API) GetCw20Balance(accountAddress string, contractAddresses []string, height *int64) (berpctypes.GenericBackendResponse, error) {
 	api.logger.Debug("wasm_getCw20Balance")
-	return api.backend.GetCw20Balance(accountAddress, contractAddresses)
+	return api.backend.GetCw20Balance(accountAddress, contractAddresses, height)
+}
+
+func (api *API) GetCw20Balances(accountAddresses []string, contractAddresses []string, height *int64) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw20Balances")
+	return api.backend.GetCw20Balances(accountAddresses, contractAddresses, height)
+}
+
+func (api *API) GetCw20BalanceHistory(accountAddress, contractAddress string, heights []int64) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw20BalanceHistory")
+	return api.backend.GetCw20BalanceHistory(accountAddress, contractAddress, heights)
+}
+
+func (api *API) GetCw20SupplyHistory(contractAddress string, fromHeight, toHeight, step int64) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw20SupplyHistory")
+	return api.backend.GetCw20SupplyHistory(contractAddress, fromHeight, toHeight, step)
+}
+
+func (api *API) GetCw20Allowance(ownerAddress, spenderAddress, contractAddress string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw20Allowance")
+	return api.backend.GetCw20Allowance(ownerAddress, spenderAddress, contractAddress)
+}
+
+func (api *API) GetCw20AllAllowances(ownerAddress, contractAddress string, startAfter *string, limit *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw20AllAllowances")
+	return api.backend.GetCw20AllAllowances(ownerAddress, contractAddress, getPageKey(startAfter), getLimit(limit))
+}
+
+func (api *API) GetCw20AllSpenderAllowances(spenderAddress, contractAddress string, startAfter *string, limit *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw20AllSpenderAllowances")
+	return api.backend.GetCw20AllSpenderAllowances(spenderAddress, contractAddress, getPageKey(startAfter), getLimit(limit))
+}
+
+func (api *API) GetCw20Holders(contractAddress string, startAfter *string, limit *int, sortByBalance *bool) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw20Holders")
+	return api.backend.GetCw20Holders(contractAddress, getPageKey(startAfter), getLimit(limit), sortByBalance != nil && *sortByBalance)
+}
+
+func (api *API) GetCw20Logo(contractAddress string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw20Logo")
+	return api.backend.GetCw20Logo(contractAddress)
+}
+
+func (api *API) GetCw20TokenMetadataCacheStats() berpctypes.GenericBackendResponse {
+	api.logger.Debug("wasm_getCw20TokenMetadataCacheStats")
+	return api.backend.GetCw20TokenMetadataCacheStats()
 }
@@ -0,0 +1,20 @@
+package wasm
+
+import (
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+)
+
+func (api *API) GetCw721ContractInfo(contractAddress string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw721ContractInfo")
+	return api.backend.GetCw721ContractInfo(contractAddress)
+}
+
+func (api *API) GetCw721Token(contractAddress, tokenId string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw721Token")
+	return api.backend.GetCw721Token(contractAddress, tokenId)
+}
+
+func (api *API) GetCw721Tokens(ownerAddress string, contractAddresses []string, startAfters map[string]string, limit *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getCw721Tokens")
+	return api.backend.GetCw721Tokens(ownerAddress, contractAddresses, startAfters, getLimit(limit))
+}
@@ -0,0 +1,19 @@
+package wasm
+
+import (
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+)
+
+func (api *API) GetTransactionByHash(hash string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("wasm_getTransactionByHash")
+	return api.backend.GetWasmTransactionByHash(hash)
+}
+
+func (api *API) GetTransactionInvolversByHash(hash string) (any, error) {
+	api.logger.Debug("wasm_getTransactionInvolversByHash")
+	involvers, err := api.backend.GetWasmTransactionInvolversByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return involvers.ToResponseObject(), nil
+}
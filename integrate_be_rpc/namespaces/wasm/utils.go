@@ -0,0 +1,17 @@
+package wasm
+
+func getPageKey(pageKeyOptional *string) string {
+	if pageKeyOptional == nil {
+		return ""
+	}
+
+	return *pageKeyOptional
+}
+
+func getLimit(limitOptional *int) int {
+	if limitOptional == nil {
+		return 0
+	}
+
+	return *limitOptional
+}
@@ -46,13 +46,20 @@ func StartWasmBeJsonRPC(
 	wasmBeRpcBackend := wasmberpcbackend.NewWasmBackend(ctx, ctx.Logger, clientCtx, externalServices)
 
 	berpc.RegisterAPINamespace(wasmbeapi.DymWasmBlockExplorerNamespace, func(ctx *server.Context,
-		_ client.Context,
+		clientCtx client.Context,
 		_ *rpcclient.WSClient,
-		_ map[string]berpctypes.MessageParser,
-		_ map[string]berpctypes.MessageInvolversExtractor,
+		messageParsers map[string]berpctypes.MessageParser,
+		messageInvolversExtractors map[string]berpctypes.MessageInvolversExtractor,
 		_ func(berpcbackend.BackendI) berpcbackend.RequestInterceptor,
-		_ berpctypes.ExternalServices,
+		externalServices berpctypes.ExternalServices,
 	) []rpc.API {
+		// the base backend without interceptor, the interceptor uses it to build the base responses
+		wasmBeRpcBackend.WithBaseBackend(berpcbackend.NewBackend(
+			ctx, ctx.Logger, clientCtx,
+			messageParsers, messageInvolversExtractors,
+			externalServices,
+		))
+
 		return []rpc.API{
 			{
 				Namespace: wasmbeapi.DymWasmBlockExplorerNamespace,
@@ -69,8 +76,8 @@ func StartWasmBeJsonRPC(
 
 	// register message parsers & message involvers extractor
 
-	bemsgparsers.RegisterMessageParsersForWasm()
-	bemsgivxtrac.RegisterMessageInvolvesExtractorsForWasm(wasmBeRpcBackend)
+	wasmBeRpcBackend.WithMessageParsers(bemsgparsers.RegisterMessageParsersForWasm(wasmBeRpcBackend))
+	wasmBeRpcBackend.WithMessageInvolversExtractors(bemsgivxtrac.RegisterMessageInvolvesExtractorsForWasm(wasmBeRpcBackend))
 
 	var interceptorCreationFunc func(berpcbackend.BackendI) berpcbackend.RequestInterceptor
 	if customInterceptorCreationFunc != nil {
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"math/big"
+	"strconv"
+)
+
+type Cw20AllowanceInfo struct {
+	Spender   string         `json:"spender,omitempty"`
+	Owner     string         `json:"owner,omitempty"`
+	Allowance string         `json:"allowance"`
+	Expires   Cw20Expiration `json:"expires"`
+}
+
+// Cw20Expiration is the `Expiration` of cw-utils, exactly one of the variants is set.
+type Cw20Expiration struct {
+	AtHeight *uint64   `json:"at_height,omitempty"`
+	AtTime   *string   `json:"at_time,omitempty"` // nanoseconds since epoch, in string
+	Never    *struct{} `json:"never,omitempty"`
+}
+
+// ToResponse returns the decoded variant of the expiration.
+func (m Cw20Expiration) ToResponse() map[string]any {
+	if m.AtHeight != nil {
+		return map[string]any{
+			"type":   "at_height",
+			"height": *m.AtHeight,
+		}
+	}
+
+	if m.AtTime != nil {
+		res := map[string]any{
+			"type": "at_time",
+			"time": *m.AtTime,
+		}
+		if nanos, err := strconv.ParseUint(*m.AtTime, 10, 64); err == nil {
+			res["timeEpochUTC"] = nanos / 1_000_000_000
+		}
+		return res
+	}
+
+	return map[string]any{
+		"type": "never",
+	}
+}
+
+// AllowanceAmount returns the allowance amount, nil if not a valid number.
+func (m Cw20AllowanceInfo) AllowanceAmount() *big.Int {
+	amount, ok := new(big.Int).SetString(m.Allowance, 10)
+	if !ok {
+		return nil
+	}
+	return amount
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCw20Expiration_ToResponse(t *testing.T) {
+	testcases := []struct {
+		name string
+		json string
+		want map[string]any
+	}{
+		{
+			name: "never",
+			json: `{"never":{}}`,
+			want: map[string]any{
+				"type": "never",
+			},
+		},
+		{
+			name: "at height",
+			json: `{"at_height":12345}`,
+			want: map[string]any{
+				"type":   "at_height",
+				"height": uint64(12345),
+			},
+		},
+		{
+			name: "at time",
+			json: `{"at_time":"1700000000123456789"}`,
+			want: map[string]any{
+				"type":         "at_time",
+				"time":         "1700000000123456789",
+				"timeEpochUTC": uint64(1700000000),
+			},
+		},
+		{
+			name: "at time, not a number",
+			json: `{"at_time":"invalid"}`,
+			want: map[string]any{
+				"type": "at_time",
+				"time": "invalid",
+			},
+		},
+		{
+			name: "no variant is considered never",
+			json: `{}`,
+			want: map[string]any{
+				"type": "never",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var expiration Cw20Expiration
+			require.NoError(t, json.Unmarshal([]byte(tc.json), &expiration))
+			require.Equal(t, tc.want, expiration.ToResponse())
+		})
+	}
+}
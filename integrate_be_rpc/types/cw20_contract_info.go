@@ -1,6 +1,9 @@
 package types
 
-import "math/big"
+import (
+	"math/big"
+	"strings"
+)
 
 type Cw20TokenInfo struct {
 	Name           string   `json:"name,omitempty"`
@@ -8,4 +11,55 @@ type Cw20TokenInfo struct {
 	Decimals       uint8    `json:"decimals"`
 	TotalSupplyStr string   `json:"total_supply,omitempty"`
 	TotalSupply    *big.Int `json:"-"`
+
+	// optional fields, not provided by the `token_info` query
+
+	Minter        *Cw20Minter        `json:"minter,omitempty"`
+	MarketingInfo *Cw20MarketingInfo `json:"marketing_info,omitempty"`
+}
+
+type Cw20Minter struct {
+	Minter string  `json:"minter"`
+	Cap    *string `json:"cap,omitempty"`
+}
+
+type Cw20MarketingInfo struct {
+	Project     string       `json:"project,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Marketing   string       `json:"marketing,omitempty"`
+	Logo        *Cw20LogoUrl `json:"logo,omitempty"` // embedded logo is not provided here
+}
+
+type Cw20LogoUrl struct {
+	Url string `json:"url"`
+}
+
+// FormatAmount returns the human-readable decimal representation of the raw amount,
+// scaled by the decimals of the token. Trailing zeros of the fractional part are removed.
+func (m Cw20TokenInfo) FormatAmount(amount *big.Int) string {
+	if amount == nil {
+		return ""
+	}
+
+	digits := new(big.Int).Abs(amount).String()
+
+	if decimals := int(m.Decimals); decimals > 0 {
+		if len(digits) <= decimals {
+			digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+		}
+
+		integralPart := digits[:len(digits)-decimals]
+		fractionalPart := strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+		digits = integralPart
+		if len(fractionalPart) > 0 {
+			digits += "." + fractionalPart
+		}
+	}
+
+	if amount.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
 }
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+func TestCw20TokenInfo_FormatAmount(t *testing.T) {
+	testcases := []struct {
+		name     string
+		decimals uint8
+		amount   *big.Int
+		want     string
+	}{
+		{
+			name:     "nil amount",
+			decimals: 6,
+			amount:   nil,
+			want:     "",
+		},
+		{
+			name:     "zero",
+			decimals: 6,
+			amount:   big.NewInt(0),
+			want:     "0",
+		},
+		{
+			name:     "no decimals",
+			decimals: 0,
+			amount:   big.NewInt(1234),
+			want:     "1234",
+		},
+		{
+			name:     "integral only",
+			decimals: 6,
+			amount:   big.NewInt(3_000_000),
+			want:     "3",
+		},
+		{
+			name:     "with fractional part",
+			decimals: 6,
+			amount:   big.NewInt(1_234_567),
+			want:     "1.234567",
+		},
+		{
+			name:     "trailing zeros of fractional part are removed",
+			decimals: 6,
+			amount:   big.NewInt(1_500_000),
+			want:     "1.5",
+		},
+		{
+			name:     "less than one",
+			decimals: 6,
+			amount:   big.NewInt(42),
+			want:     "0.000042",
+		},
+		{
+			name:     "length equals to decimals",
+			decimals: 3,
+			amount:   big.NewInt(123),
+			want:     "0.123",
+		},
+		{
+			name:     "negative",
+			decimals: 2,
+			amount:   big.NewInt(-150),
+			want:     "-1.5",
+		},
+		{
+			name:     "larger than 64 bits",
+			decimals: 18,
+			amount: func() *big.Int {
+				amount, _ := new(big.Int).SetString("123456789000000000000000000001", 10)
+				return amount
+			}(),
+			want: "123456789000.000000000000000001",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Cw20TokenInfo{Decimals: tc.decimals}.FormatAmount(tc.amount)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package types
+
+type Cw721CollectionInfo struct {
+	Name   string `json:"name,omitempty"`
+	Symbol string `json:"symbol,omitempty"`
+
+	// optional fields, not provided by the `contract_info` query
+
+	NumTokens *uint64 `json:"num_tokens,omitempty"`
+	Minter    string  `json:"minter,omitempty"`
+}
+
+type Cw721NumTokens struct {
+	Count uint64 `json:"count"`
+}
+
+type Cw721Minter struct {
+	Minter *string `json:"minter,omitempty"`
+}
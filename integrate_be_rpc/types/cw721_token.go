@@ -0,0 +1,43 @@
+package types
+
+import "encoding/json"
+
+// Cw721AllNftInfo is the response of the `all_nft_info` query of CW-721 contracts.
+type Cw721AllNftInfo struct {
+	Access Cw721Access  `json:"access"`
+	Info   Cw721NftInfo `json:"info"`
+}
+
+type Cw721Access struct {
+	Owner     string          `json:"owner"`
+	Approvals []Cw721Approval `json:"approvals"`
+}
+
+type Cw721Approval struct {
+	Spender string         `json:"spender"`
+	Expires Cw20Expiration `json:"expires"` // same `Expiration` of cw-utils
+}
+
+type Cw721NftInfo struct {
+	TokenUri  *string         `json:"token_uri,omitempty"`
+	Extension json.RawMessage `json:"extension,omitempty"` // schema depends on the contract
+}
+
+// Cw721Metadata is the on-chain metadata of the cw721-metadata-onchain schema.
+type Cw721Metadata struct {
+	Image           *string          `json:"image,omitempty"`
+	ImageData       *string          `json:"image_data,omitempty"`
+	ExternalUrl     *string          `json:"external_url,omitempty"`
+	Description     *string          `json:"description,omitempty"`
+	Name            *string          `json:"name,omitempty"`
+	Attributes      []Cw721Attribute `json:"attributes,omitempty"`
+	BackgroundColor *string          `json:"background_color,omitempty"`
+	AnimationUrl    *string          `json:"animation_url,omitempty"`
+	YoutubeUrl      *string          `json:"youtube_url,omitempty"`
+}
+
+type Cw721Attribute struct {
+	DisplayType *string `json:"display_type,omitempty"`
+	TraitType   string  `json:"trait_type"`
+	Value       string  `json:"value"`
+}